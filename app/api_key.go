@@ -0,0 +1,24 @@
+package app
+
+import (
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"time"
+)
+
+func NewApiKeys() []domain.ApiKey {
+	return []domain.ApiKey{
+		{Key: "RAHASIA", Name: "default", Role: domain.RoleUser, Secret: "RAHASIA_SIGNING_SECRET"},
+		{Key: "RAHASIA_ADMIN", Name: "admin", Role: domain.RoleAdmin, TwoFactorExempt: true},
+	}
+}
+
+func NewHmacConfig() middleware.HmacConfig {
+	return middleware.HmacConfig{
+		ClockSkew: 5 * time.Minute,
+	}
+}
+
+func NewTwoFactorPolicy() *middleware.TwoFactorPolicy {
+	return middleware.NewTwoFactorPolicy([]string{domain.RoleAdmin})
+}
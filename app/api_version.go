@@ -0,0 +1,31 @@
+package app
+
+import (
+	"project-restful-api/middleware"
+	"time"
+)
+
+type ApiVersions struct {
+	Resources []string
+	Default   string
+	Policies  []middleware.ApiVersionPolicy
+}
+
+func NewApiVersions() ApiVersions {
+	return ApiVersions{
+		Resources: []string{"categories"},
+		Default:   "v1",
+		Policies: []middleware.ApiVersionPolicy{
+			{
+				Version:      "v1",
+				DeprecatedAt: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+				SunsetAt:     time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+				Successor:    "v2",
+			},
+			{
+				Version:  "v2",
+				Envelope: true,
+			},
+		},
+	}
+}
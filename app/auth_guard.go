@@ -0,0 +1,21 @@
+package app
+
+import (
+	"project-restful-api/middleware"
+	"time"
+)
+
+func NewAuthGuardConfig() middleware.AuthGuardConfig {
+	return middleware.AuthGuardConfig{
+		MaxFailures:   5,
+		FailureWindow: 15 * time.Minute,
+		BaseLockout:   30 * time.Second,
+		MaxLockout:    time.Hour,
+		KeyPrefixSize: 8,
+		EventLogSize:  1000,
+	}
+}
+
+func NewAuthAlertHook() middleware.AuthAlertHook {
+	return middleware.LogAuthAlert
+}
@@ -0,0 +1,15 @@
+package app
+
+import (
+	"project-restful-api/model/domain"
+	"time"
+)
+
+var Version = "dev"
+
+func NewBuildInfo() domain.BuildInfo {
+	return domain.BuildInfo{
+		Version:   Version,
+		StartedAt: time.Now(),
+	}
+}
@@ -0,0 +1,13 @@
+package app
+
+import (
+	"compress/gzip"
+	"project-restful-api/middleware"
+)
+
+func NewCompressionConfig() middleware.CompressionConfig {
+	return middleware.CompressionConfig{
+		MinSize: 1024,
+		Level:   gzip.DefaultCompression,
+	}
+}
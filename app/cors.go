@@ -0,0 +1,41 @@
+package app
+
+import (
+	"net/http"
+	"project-restful-api/middleware"
+	"time"
+)
+
+func NewCorsConfig() middleware.CorsConfig {
+	return middleware.CorsConfig{
+		AllowedOrigins: []string{
+			"http://localhost:*",
+			"http://127.0.0.1:*",
+		},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders: []string{
+			"Accept",
+			"Authorization",
+			"Content-Type",
+			"X-API-Key",
+			"X-Request-ID",
+			"X-Timestamp",
+			"X-Nonce",
+			"traceparent",
+		},
+		ExposedHeaders: []string{
+			"Deprecation",
+			"ETag",
+			"Link",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"Retry-After",
+			"Sunset",
+			"X-Request-ID",
+			"traceparent",
+		},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
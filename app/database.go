@@ -1,19 +1,28 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"project-restful-api/helper"
+	"project-restful-api/metrics"
 	"time"
 )
 
 func NewDB() *sql.DB {
-	db, err := sql.Open("mysql", "root:Ulang.ko.PutusAsa.daa.mang.17@tcp(localhost:3306)/belajar_golang_restful_api")
+	db, err := sql.Open("mysql", "root:Ulang.ko.PutusAsa.daa.mang.17@tcp(localhost:3306)/belajar_golang_restful_api?parseTime=true")
 	helper.PanicIfError(err)
 	
 	db.SetMaxIdleConns(5)
 	db.SetMaxOpenConns(20)
 	db.SetConnMaxLifetime(60 * time.Minute)
 	db.SetConnMaxIdleTime(10 * time.Minute)
-	
+	metrics.RegisterDBStats(metrics.DefaultRegistry, db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		helper.LogError("database is not reachable yet", err)
+	}
+
 	return db
 }
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"project-restful-api/event"
+	"time"
+)
+
+func NewEventBroker() *event.Broker {
+	return event.NewBroker(event.BrokerConfig{
+		ReplaySize:       1000,
+		SubscriberBuffer: 64,
+	})
+}
+
+func NewEventStreamConfig(serverConfig ServerConfig) event.StreamConfig {
+	maxDuration := serverConfig.WriteTimeout - 5*time.Second
+	if maxDuration <= 0 {
+		maxDuration = serverConfig.WriteTimeout / 2
+	}
+	if maxDuration <= 0 {
+		maxDuration = time.Hour
+	}
+
+	return event.StreamConfig{
+		Heartbeat:   15 * time.Second,
+		Retry:       3 * time.Second,
+		MaxDuration: maxDuration,
+	}
+}
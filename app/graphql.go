@@ -0,0 +1,10 @@
+package app
+
+import "project-restful-api/graphql"
+
+func NewGraphqlLimits() graphql.Limits {
+	return graphql.Limits{
+		MaxDepth:      8,
+		MaxComplexity: 1000,
+	}
+}
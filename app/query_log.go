@@ -0,0 +1,14 @@
+package app
+
+import (
+	"project-restful-api/repository"
+	"time"
+)
+
+func NewQueryLogConfig() repository.QueryLogConfig {
+	return repository.QueryLogConfig{
+		SlowThreshold:  200 * time.Millisecond,
+		LogStatements:  false,
+		MaxFingerprint: 500,
+	}
+}
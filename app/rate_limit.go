@@ -0,0 +1,25 @@
+package app
+
+import (
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"time"
+)
+
+type RateLimits struct {
+	Public        middleware.RateLimitConfig
+	Authenticated middleware.RateLimitConfig
+	Admin         middleware.RateLimitConfig
+}
+
+func NewRateLimits() RateLimits {
+	return RateLimits{
+		Public:        middleware.RateLimitConfig{Limit: 60, Period: time.Minute},
+		Authenticated: middleware.RateLimitConfig{Limit: 300, Period: time.Minute},
+		Admin:         middleware.RateLimitConfig{Limit: 60, Period: time.Minute},
+	}
+}
+
+func NewTrustedProxies() helper.TrustedProxies {
+	return helper.ParseTrustedProxies("127.0.0.1/32", "::1/128")
+}
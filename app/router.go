@@ -1,21 +1,118 @@
 package app
 
 import (
+	"net/http"
 	"project-restful-api/controller"
 	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/metrics"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/tracing"
 
 	"github.com/julienschmidt/httprouter"
 )
 
-func NewRouter(categoryController controller.CategoryController) *httprouter.Router{
+type routeGroups struct {
+	Probe         middleware.Middleware
+	Public        middleware.Middleware
+	Authenticated middleware.Middleware
+	Admin         middleware.Middleware
+}
+
+func newRouteGroups(authMiddleware *middleware.AuthMiddleware, rateLimitMiddleware *middleware.RateLimitMiddleware, rateLimits RateLimits) routeGroups {
+	return routeGroups{
+		Probe: middleware.Chain(),
+		Public: middleware.Chain(
+			rateLimitMiddleware.Limit("public", rateLimits.Public),
+		),
+		Authenticated: middleware.Chain(
+			authMiddleware.Authenticate,
+			rateLimitMiddleware.Limit("authenticated", rateLimits.Authenticated),
+		),
+		Admin: middleware.Chain(
+			authMiddleware.Authenticate,
+			authMiddleware.RequireRole(domain.RoleAdmin),
+			rateLimitMiddleware.Limit("admin", rateLimits.Admin),
+		),
+	}
+}
+
+func NewRouter(categoryController controller.CategoryController, graphqlController controller.GraphqlController, rpcController controller.RpcController, eventController controller.EventController, webSocketController controller.WebSocketController, webhookController controller.WebhookController, authController controller.AuthController, adminController controller.AdminController, healthController controller.HealthController, authMiddleware *middleware.AuthMiddleware, rateLimitMiddleware *middleware.RateLimitMiddleware, rateLimits RateLimits, apiVersions ApiVersions) *httprouter.Router {
 	router := httprouter.New()
+	groups := newRouteGroups(authMiddleware, rateLimitMiddleware, rateLimits)
+	route := func(method string, path string, group middleware.Middleware, handle httprouter.Handle) {
+		router.Handle(method, path, middleware.Chain(middleware.Route(path), group)(handle))
+	}
+
+	route(http.MethodGet, "/healthz", groups.Probe, healthController.Liveness)
+	route(http.MethodGet, "/readyz", groups.Probe, healthController.Readiness)
+	route(http.MethodGet, "/metrics", groups.Probe, serveMetrics)
+
+	route(http.MethodGet, "/api/openapi.json", groups.Public, serveApiSpec)
+
+	categoryRelations := categoryController.Relations()
+	categoryResponse := middleware.Chain(
+		middleware.JsonApiResource("categories", categoryRelations),
+		middleware.ResponseShape(web.CategoryResponse{}, categoryRelations),
+	)
+	for _, policy := range apiVersions.Policies {
+		prefix := "/api/" + policy.Version
+		versioned := middleware.Chain(middleware.ApiVersion(policy), groups.Authenticated, categoryResponse)
+
+		route(http.MethodGet, prefix+"/categories", versioned, categoryController.FindAll)
+		route(http.MethodGet, prefix+"/categories/:categoryId", versioned, categoryController.FindById)
+		route(http.MethodPost, prefix+"/categories", versioned, categoryController.Create)
+		route(http.MethodPut, prefix+"/categories/:categoryId", versioned, categoryController.Update)
+		route(http.MethodDelete, prefix+"/categories/:categoryId", versioned, categoryController.Delete)
+	}
+
+	route(http.MethodPost, "/graphql", groups.Authenticated, graphqlController.Execute)
+	route(http.MethodPost, "/rpc", groups.Authenticated, rpcController.Call)
+	route(http.MethodGet, "/api/events", groups.Authenticated, eventController.Stream)
+	route(http.MethodGet, "/api/ws", groups.Authenticated, webSocketController.Connect)
 
-	router.GET("/api/categories", categoryController.FindAll)
-	router.GET("/api/categories/:categoryId", categoryController.FindById)
-	router.POST("/api/categories", categoryController.Create)
-	router.PUT("/api/categories/:categoryId", categoryController.Update)
-	router.DELETE("/api/categories/:categoryId", categoryController.Delete)
+	route(http.MethodPost, "/api/auth/login", groups.Public, authController.Login)
+	route(http.MethodPost, "/api/auth/totp/enroll", groups.Authenticated, authController.EnrollTotp)
+	route(http.MethodPost, "/api/auth/totp/activate", groups.Authenticated, authController.ActivateTotp)
 
+	route(http.MethodGet, "/api/admin/status", groups.Admin, healthController.Status)
+	route(http.MethodGet, "/api/admin/auth-failures", groups.Admin, adminController.AuthFailures)
+	route(http.MethodPost, "/api/admin/users", groups.Admin, adminController.CreateUser)
+	route(http.MethodGet, "/api/admin/two-factor-policy", groups.Admin, adminController.GetTwoFactorPolicy)
+	route(http.MethodPut, "/api/admin/two-factor-policy", groups.Admin, adminController.UpdateTwoFactorPolicy)
+	route(http.MethodGet, "/api/admin/debug/queries", groups.Admin, adminController.SlowQueries)
+
+	route(http.MethodGet, "/api/webhooks", groups.Admin, webhookController.FindAll)
+	route(http.MethodPost, "/api/webhooks", groups.Admin, webhookController.Create)
+	route(http.MethodGet, "/api/webhooks/:webhookId", groups.Admin, webhookController.FindById)
+	route(http.MethodPut, "/api/webhooks/:webhookId", groups.Admin, webhookController.Update)
+	route(http.MethodDelete, "/api/webhooks/:webhookId", groups.Admin, webhookController.Delete)
+	route(http.MethodGet, "/api/webhooks/:webhookId/deliveries", groups.Admin, webhookController.Deliveries)
+	route(http.MethodPost, "/api/webhooks/:webhookId/deliveries/:deliveryId/redeliver", groups.Admin, webhookController.Redeliver)
+
+	router.NotFound = http.HandlerFunc(exception.NotFoundHandler)
+	router.MethodNotAllowed = http.HandlerFunc(exception.MethodNotAllowedHandler)
+	router.GlobalOPTIONS = http.HandlerFunc(exception.OptionsHandler)
 	router.PanicHandler = exception.ErrorHandler
 	return router
 }
+
+func serveApiSpec(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	writer.Header().Set("Content-Type", "application/json")
+	http.ServeFile(writer, request, "apispec.json")
+}
+
+func serveMetrics(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	metrics.DefaultRegistry.ServeHTTP(writer, request)
+}
+
+func NewHandler(router *httprouter.Router, trustedProxies helper.TrustedProxies, tracer *tracing.Tracer, serverConfig ServerConfig, corsConfig middleware.CorsConfig, compressionConfig middleware.CompressionConfig, apiVersions ApiVersions) http.Handler {
+	var handler http.Handler = middleware.NewApiVersionMiddleware(router, apiVersions.Resources, apiVersions.Policies, apiVersions.Default)
+	handler = middleware.NewBodyLimitMiddleware(handler, serverConfig.MaxBodyBytes)
+	handler = middleware.NewNegotiationMiddleware(handler, helper.DefaultCodecs)
+	handler = middleware.NewCorsMiddleware(handler, corsConfig)
+	handler = middleware.NewCompressionMiddleware(handler, compressionConfig)
+	return middleware.NewAccessLogMiddleware(middleware.NewTracingMiddleware(middleware.NewMetricsMiddleware(handler), tracer), trustedProxies)
+}
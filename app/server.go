@@ -0,0 +1,46 @@
+package app
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type ServerConfig struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	MaxBodyBytes      int64
+	ShutdownTimeout   time.Duration
+}
+
+func NewServerConfig() ServerConfig {
+	return ServerConfig{
+		Addr:              "localhost:3000",
+		ReadTimeout:       15 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		MaxBodyBytes:      1 << 20,
+		ShutdownTimeout:   30 * time.Second,
+	}
+}
+
+type Readiness struct {
+	ready atomic.Bool
+}
+
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+func (readiness *Readiness) Ready() bool {
+	return readiness.ready.Load()
+}
+
+func (readiness *Readiness) SetReady(ready bool) {
+	readiness.ready.Store(ready)
+}
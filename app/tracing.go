@@ -0,0 +1,51 @@
+package app
+
+import (
+	"os"
+	"project-restful-api/helper"
+	"project-restful-api/tracing"
+)
+
+type TracingConfig struct {
+	Exporter     string
+	FilePath     string
+	OtlpEndpoint string
+	ServiceName  string
+}
+
+func NewTracingConfig() TracingConfig {
+	config := TracingConfig{
+		Exporter:     "none",
+		FilePath:     "traces.jsonl",
+		OtlpEndpoint: "http://localhost:4318",
+		ServiceName:  "todolist-api",
+	}
+	if exporter := os.Getenv("TRACING_EXPORTER"); exporter != "" {
+		config.Exporter = exporter
+	}
+	if filePath := os.Getenv("TRACING_FILE"); filePath != "" {
+		config.FilePath = filePath
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		config.OtlpEndpoint = endpoint
+	}
+	return config
+}
+
+func NewTracer(config TracingConfig) *tracing.Tracer {
+	var exporter tracing.Exporter
+	switch config.Exporter {
+	case "stdout":
+		exporter = tracing.NewStdoutExporter()
+	case "file":
+		fileExporter, err := tracing.NewFileExporter(config.FilePath)
+		helper.PanicIfError(err)
+		exporter = fileExporter
+	case "otlp":
+		exporter = tracing.NewOtlpHttpExporter(config.OtlpEndpoint, config.ServiceName)
+	}
+
+	tracer := tracing.NewTracer(exporter)
+	tracing.SetDefault(tracer)
+	return tracer
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"project-restful-api/webhook"
+	"time"
+)
+
+func NewWebhookConfig() webhook.Config {
+	return webhook.Config{
+		Workers:      4,
+		BatchSize:    20,
+		PollInterval: time.Second,
+		Timeout:      10 * time.Second,
+		Lease:        time.Minute,
+		MaxAttempts:  8,
+		DisableAfter: 20,
+		Backoff: webhook.Backoff{
+			Initial:    10 * time.Second,
+			Max:        time.Hour,
+			Multiplier: 3,
+		},
+	}
+}
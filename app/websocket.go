@@ -0,0 +1,21 @@
+package app
+
+import (
+	"project-restful-api/middleware"
+	"project-restful-api/websocket"
+	"time"
+)
+
+const WebSocketSubprotocol = "todolist.v1"
+
+func NewWebSocketConfig(corsConfig middleware.CorsConfig) websocket.Config {
+	return websocket.Config{
+		MaxMessageSize: 64 << 10,
+		SendQueueSize:  64,
+		PingInterval:   30 * time.Second,
+		PongTimeout:    75 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		Subprotocols:   []string{WebSocketSubprotocol},
+		CheckOrigin:    corsConfig.AllowsOrigin,
+	}
+}
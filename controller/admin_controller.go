@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AdminController interface {
+	AuthFailures(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	CreateUser(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	GetTwoFactorPolicy(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateTwoFactorPolicy(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	SlowQueries(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
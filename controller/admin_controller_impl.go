@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"project-restful-api/repository"
+	"project-restful-api/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+)
+
+type AdminControllerImpl struct {
+	AuthGuard       *middleware.AuthGuard
+	TwoFactorPolicy *middleware.TwoFactorPolicy
+	UserService     service.UserService
+	QueryLog        *repository.QueryLog
+	Validate        *validator.Validate
+}
+
+func NewAdminController(authGuard *middleware.AuthGuard, twoFactorPolicy *middleware.TwoFactorPolicy, userService service.UserService, queryLog *repository.QueryLog, validate *validator.Validate) AdminController {
+	return &AdminControllerImpl{
+		AuthGuard:       authGuard,
+		TwoFactorPolicy: twoFactorPolicy,
+		UserService:     userService,
+		QueryLog:        queryLog,
+		Validate:        validate,
+	}
+}
+
+func (controller *AdminControllerImpl) AuthFailures(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	authFailureQuery := web.AuthFailureQuery{Limit: 50}
+	helper.Bind(request, params, &authFailureQuery, controller.Validate)
+
+	failures := controller.AuthGuard.Events(authFailureQuery.Limit, authFailureQuery.ClientIP, authFailureQuery.KeyPrefix)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToAuthFailureResponses(failures),
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AdminControllerImpl) CreateUser(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	userCreateRequest := web.UserCreateRequest{}
+	helper.ReadFromRequestBodyStrict(request, &userCreateRequest)
+
+	userResponse := controller.UserService.Create(request.Context(), userCreateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AdminControllerImpl) GetTwoFactorPolicy(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   web.TwoFactorPolicyResponse{Roles: controller.TwoFactorPolicy.Roles()},
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AdminControllerImpl) UpdateTwoFactorPolicy(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	policyRequest := web.TwoFactorPolicyRequest{}
+	helper.ReadFromRequestBodyStrict(request, &policyRequest)
+
+	err := controller.Validate.Struct(policyRequest)
+	helper.PanicIfError(err)
+
+	controller.TwoFactorPolicy.SetRoles(policyRequest.Roles)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   web.TwoFactorPolicyResponse{Roles: controller.TwoFactorPolicy.Roles()},
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AdminControllerImpl) SlowQueries(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	slowQueryQuery := web.SlowQueryQuery{Limit: 10}
+	helper.Bind(request, params, &slowQueryQuery, controller.Validate)
+
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToQueryStatResponses(controller.QueryLog.Slowest(slowQueryQuery.Limit)),
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
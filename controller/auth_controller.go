@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuthController interface {
+	Login(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	EnrollTotp(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ActivateTotp(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuthControllerImpl struct {
+	UserService service.UserService
+}
+
+func NewAuthController(userService service.UserService) AuthController {
+	return &AuthControllerImpl{
+		UserService: userService,
+	}
+}
+
+func (controller *AuthControllerImpl) Login(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	loginRequest := web.LoginRequest{}
+	helper.ReadFromRequestBodyStrict(request, &loginRequest)
+
+	loginResponse := controller.UserService.Login(request.Context(), loginRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   loginResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AuthControllerImpl) EnrollTotp(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	enrollResponse := controller.UserService.EnrollTotp(request.Context(), currentUserId(request))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   enrollResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *AuthControllerImpl) ActivateTotp(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	activateRequest := web.TotpActivateRequest{}
+	helper.ReadFromRequestBodyStrict(request, &activateRequest)
+
+	userResponse := controller.UserService.ActivateTotp(request.Context(), currentUserId(request), activateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func currentUserId(request *http.Request) int {
+	principal, ok := middleware.PrincipalFromContext(request.Context())
+	if !ok || principal.UserId == 0 {
+		panic(exception.NewForbiddenError("a user session is required"))
+	}
+	return principal.UserId
+}
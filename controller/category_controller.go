@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"project-restful-api/helper"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -12,4 +13,5 @@ type CategoryController interface {
 	Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Relations() helper.Relations
 }
@@ -1,29 +1,36 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 	"project-restful-api/helper"
 	"project-restful-api/model/web"
 	"project-restful-api/service"
-	"strconv"
+	"project-restful-api/tracing"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/julienschmidt/httprouter"
 )
 
 type CategoryControllerImpl struct {
 	CategoryService service.CategoryService
+	Validate        *validator.Validate
 }
 
-func NewCategoryController(categoryService service.CategoryService) CategoryController {
+func NewCategoryController(categoryService service.CategoryService, validate *validator.Validate) CategoryController {
 	return &CategoryControllerImpl{
 		CategoryService: categoryService,
+		Validate:        validate,
 	}
 }
 
 func (controller *CategoryControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "CategoryController.Create")
+	defer span.End()
+
 	categoryCreateRequest := web.CategoryCreateRequest{}
-	helper.ReadFromRequestBody(request, &categoryCreateRequest)
-	categoryResponse := controller.CategoryService.Create(request.Context(), categoryCreateRequest)
+	helper.Bind(request, params, &categoryCreateRequest, controller.Validate)
+	categoryResponse := controller.CategoryService.Create(ctx, categoryCreateRequest)
 	
 	webResponse := web.WebResponse{
 		Code: 200,
@@ -33,15 +40,13 @@ func (controller *CategoryControllerImpl) Create(writer http.ResponseWriter, req
 	helper.WriteToResponseBody(writer, webResponse)
 }
 func (controller *CategoryControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "CategoryController.Update")
+	defer span.End()
+
 	categoryUpdateRequest := web.CategoryUpdateRequest{}
-	helper.ReadFromRequestBody(request, &categoryUpdateRequest)
-	
-	categoryId := params.ByName("categoryId")
-	IdToInt, err := strconv.Atoi(categoryId)
-	helper.PanicIfError(err)
-	categoryUpdateRequest.Id = IdToInt
+	helper.Bind(request, params, &categoryUpdateRequest, controller.Validate)
 
-	categoryResponse := controller.CategoryService.Update(request.Context(), categoryUpdateRequest)
+	categoryResponse := controller.CategoryService.Update(ctx, categoryUpdateRequest)
 	webResponse := web.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -50,11 +55,13 @@ func (controller *CategoryControllerImpl) Update(writer http.ResponseWriter, req
 	helper.WriteToResponseBody(writer, webResponse)
 }
 func (controller *CategoryControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	categoryId := params.ByName("categoryId")
-	id, err := strconv.Atoi(categoryId)
-	helper.PanicIfError(err)
+	ctx, span := tracing.Start(request.Context(), "CategoryController.Delete")
+	defer span.End()
 
-	controller.CategoryService.Delete(request.Context(), id)
+	categoryIdRequest := web.CategoryIdRequest{}
+	helper.Bind(request, params, &categoryIdRequest, controller.Validate)
+
+	controller.CategoryService.Delete(ctx, categoryIdRequest.Id)
 	
 	webResponse := web.WebResponse{
 		Code:   200,
@@ -63,11 +70,13 @@ func (controller *CategoryControllerImpl) Delete(writer http.ResponseWriter, req
 	helper.WriteToResponseBody(writer, webResponse)
 }
 func (controller *CategoryControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	categoryId := params.ByName("categoryId")
-	id, err := strconv.Atoi(categoryId)
-	helper.PanicIfError(err)
+	ctx, span := tracing.Start(request.Context(), "CategoryController.FindById")
+	defer span.End()
+
+	categoryIdRequest := web.CategoryIdRequest{}
+	helper.Bind(request, params, &categoryIdRequest, controller.Validate)
 
-	categoryResponse := controller.CategoryService.FindById(request.Context(), id)
+	categoryResponse := controller.CategoryService.FindById(ctx, categoryIdRequest.Id)
 	
 	webResponse := web.WebResponse{
 		Code:   200,
@@ -77,7 +86,10 @@ func (controller *CategoryControllerImpl) FindById(writer http.ResponseWriter, r
 	helper.WriteToResponseBody(writer, webResponse)
 }
 func (controller *CategoryControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	categoryResponses := controller.CategoryService.FindAll(request.Context())
+	ctx, span := tracing.Start(request.Context(), "CategoryController.FindAll")
+	defer span.End()
+
+	categoryResponses := controller.CategoryService.FindAll(ctx)
 	webResponses := web.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -85,3 +97,28 @@ func (controller *CategoryControllerImpl) FindAll(writer http.ResponseWriter, re
 	}
 	helper.WriteToResponseBody(writer, webResponses)
 }
+
+func (controller *CategoryControllerImpl) Relations() helper.Relations {
+	return helper.Relations{
+		"parent": {
+			Type: "categories",
+			Load: func(ctx context.Context, ids []int) map[int]interface{} {
+				loaded := map[int]interface{}{}
+				for categoryId, parent := range controller.CategoryService.FindParents(ctx, ids) {
+					loaded[categoryId] = parent
+				}
+				return loaded
+			},
+		},
+		"todos": {
+			Type: "todos",
+			Load: func(ctx context.Context, ids []int) map[int]interface{} {
+				loaded := map[int]interface{}{}
+				for categoryId, todos := range controller.CategoryService.FindTodos(ctx, ids) {
+					loaded[categoryId] = todos
+				}
+				return loaded
+			},
+		},
+	}
+}
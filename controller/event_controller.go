@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type EventController interface {
+	Stream(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
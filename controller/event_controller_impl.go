@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"project-restful-api/event"
+	"project-restful-api/helper"
+	"project-restful-api/model/domain"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type EventControllerImpl struct {
+	Broker *event.Broker
+	Config event.StreamConfig
+}
+
+func NewEventController(broker *event.Broker, config event.StreamConfig) EventController {
+	return &EventControllerImpl{
+		Broker: broker,
+		Config: config,
+	}
+}
+
+func (controller *EventControllerImpl) Stream(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	types := parseEventTypes(request.URL.Query().Get("types"))
+
+	lastEventIdValue := request.Header.Get("Last-Event-ID")
+	if lastEventIdValue == "" {
+		lastEventIdValue = request.URL.Query().Get("lastEventId")
+	}
+	lastEventId, err := event.ParseLastEventId(lastEventIdValue)
+	if err != nil {
+		panic(helper.BindError{Source: "header", Field: "Last-Event-ID", Message: "must be a non-negative integer"})
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		panic(errors.New("event stream requires a flushable response writer"))
+	}
+
+	subscription, replay := controller.Broker.Subscribe(types, lastEventId)
+	defer subscription.Close()
+
+	writer.Header().Set("Content-Type", event.StreamContentType)
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+
+	if event.WriteRetry(writer, controller.Config.Retry) != nil {
+		return
+	}
+	for _, replayed := range replay {
+		if event.WriteServerSentEvent(writer, replayed) != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(controller.Config.Heartbeat)
+	defer heartbeat.Stop()
+	var expired <-chan time.Time
+	if controller.Config.MaxDuration > 0 {
+		timer := time.NewTimer(controller.Config.MaxDuration)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-expired:
+			return
+		case <-heartbeat.C:
+			if event.WriteComment(writer, "heartbeat") != nil {
+				return
+			}
+		case published, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if event.WriteServerSentEvent(writer, published) != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func parseEventTypes(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, eventType := range domain.CategoryEventTypes {
+		known[eventType] = true
+	}
+
+	var types []string
+	for _, eventType := range strings.Split(value, ",") {
+		eventType = strings.TrimSpace(eventType)
+		if !known[eventType] {
+			panic(helper.BindError{Source: "query", Field: "types", Message: "must be one of " + strings.Join(domain.CategoryEventTypes, ", ")})
+		}
+		types = append(types, eventType)
+	}
+	return types
+}
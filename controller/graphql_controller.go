@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type GraphqlController interface {
+	Execute(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+	"project-restful-api/graphql"
+	"project-restful-api/helper"
+	"project-restful-api/tracing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type GraphqlControllerImpl struct {
+	Schema *graphql.Schema
+	Limits graphql.Limits
+}
+
+func NewGraphqlController(schema *graphql.Schema, limits graphql.Limits) GraphqlController {
+	return &GraphqlControllerImpl{
+		Schema: schema,
+		Limits: limits,
+	}
+}
+
+func (controller *GraphqlControllerImpl) Execute(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "GraphqlController.Execute")
+	defer span.End()
+
+	graphqlRequest := graphql.Request{}
+	helper.ReadFromRequestBody(request, &graphqlRequest)
+	span.SetAttribute("graphql.operation.name", graphqlRequest.OperationName)
+
+	response := controller.Schema.Execute(ctx, graphqlRequest, controller.Limits)
+	helper.WriteToResponseBody(writer, response)
+}
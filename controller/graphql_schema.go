@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"project-restful-api/exception"
+	"project-restful-api/graphql"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	categoryCursorPrefix    = "category:"
+	defaultCategoryPageSize = 20
+	maxCategoryPageSize     = 100
+)
+
+var categoryType = &graphql.Object{
+	Name: "Category",
+	Fields: graphql.Fields{
+		"id":   {Type: &graphql.NonNull{Of: graphql.ID}},
+		"name": {Type: &graphql.NonNull{Of: graphql.String}},
+	},
+}
+
+var categoryEdgeType = &graphql.Object{
+	Name: "CategoryEdge",
+	Fields: graphql.Fields{
+		"cursor": {Type: &graphql.NonNull{Of: graphql.String}},
+		"node":   {Type: &graphql.NonNull{Of: categoryType}},
+	},
+}
+
+var pageInfoType = &graphql.Object{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage":     {Type: &graphql.NonNull{Of: graphql.Boolean}},
+		"hasPreviousPage": {Type: &graphql.NonNull{Of: graphql.Boolean}},
+		"startCursor":     {Type: graphql.String},
+		"endCursor":       {Type: graphql.String},
+	},
+}
+
+var categoryConnectionType = &graphql.Object{
+	Name: "CategoryConnection",
+	Fields: graphql.Fields{
+		"edges":      {Type: &graphql.NonNull{Of: &graphql.List{Of: &graphql.NonNull{Of: categoryEdgeType}}}},
+		"nodes":      {Type: &graphql.NonNull{Of: &graphql.List{Of: &graphql.NonNull{Of: categoryType}}}},
+		"pageInfo":   {Type: &graphql.NonNull{Of: pageInfoType}},
+		"totalCount": {Type: &graphql.NonNull{Of: graphql.Int}},
+	},
+}
+
+var categoryFilterType = &graphql.InputObject{
+	Name: "CategoryFilter",
+	Fields: []*graphql.ArgumentDefinition{
+		{Name: "name", Type: graphql.String},
+		{Name: "ids", Type: &graphql.List{Of: &graphql.NonNull{Of: graphql.ID}}},
+	},
+}
+
+var categoryInputType = &graphql.InputObject{
+	Name: "CategoryInput",
+	Fields: []*graphql.ArgumentDefinition{
+		{Name: "name", Type: &graphql.NonNull{Of: graphql.String}},
+	},
+}
+
+func NewGraphqlSchema(categoryService service.CategoryService) *graphql.Schema {
+	authenticated := authorizeRoles(domain.RoleUser, domain.RoleAdmin)
+	return &graphql.Schema{
+		Query: &graphql.Object{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"category": {
+					Type:      categoryType,
+					Args:      []*graphql.ArgumentDefinition{{Name: "id", Type: &graphql.NonNull{Of: graphql.ID}}},
+					Authorize: authenticated,
+					Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+						categoryId, err := graphqlId(params.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						return categoryService.FindById(params.Context, categoryId), nil
+					},
+				},
+				"categories": {
+					Type: &graphql.NonNull{Of: categoryConnectionType},
+					Args: []*graphql.ArgumentDefinition{
+						{Name: "first", Type: graphql.Int, Default: defaultCategoryPageSize},
+						{Name: "after", Type: graphql.String},
+						{Name: "filter", Type: categoryFilterType},
+					},
+					Authorize: authenticated,
+					Complexity: func(args map[string]interface{}, childComplexity int) int {
+						first, _ := args["first"].(int)
+						if first < 0 {
+							first = 0
+						} else if first > maxCategoryPageSize {
+							first = maxCategoryPageSize
+						}
+						return 1 + first*childComplexity
+					},
+					Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+						return resolveCategoryConnection(categoryService.FindAll(params.Context), params.Args)
+					},
+				},
+			},
+		},
+		Mutation: &graphql.Object{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createCategory": {
+					Type:      &graphql.NonNull{Of: categoryType},
+					Args:      []*graphql.ArgumentDefinition{{Name: "input", Type: &graphql.NonNull{Of: categoryInputType}}},
+					Authorize: authenticated,
+					Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+						input := params.Args["input"].(map[string]interface{})
+						return categoryService.Create(params.Context, web.CategoryCreateRequest{Name: input["name"].(string)}), nil
+					},
+				},
+				"updateCategory": {
+					Type: &graphql.NonNull{Of: categoryType},
+					Args: []*graphql.ArgumentDefinition{
+						{Name: "id", Type: &graphql.NonNull{Of: graphql.ID}},
+						{Name: "input", Type: &graphql.NonNull{Of: categoryInputType}},
+					},
+					Authorize: authenticated,
+					Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+						categoryId, err := graphqlId(params.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						input := params.Args["input"].(map[string]interface{})
+						return categoryService.Update(params.Context, web.CategoryUpdateRequest{Id: categoryId, Name: input["name"].(string)}), nil
+					},
+				},
+				"deleteCategory": {
+					Type:      &graphql.NonNull{Of: graphql.Boolean},
+					Args:      []*graphql.ArgumentDefinition{{Name: "id", Type: &graphql.NonNull{Of: graphql.ID}}},
+					Authorize: authenticated,
+					Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+						categoryId, err := graphqlId(params.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						categoryService.Delete(params.Context, categoryId)
+						return true, nil
+					},
+				},
+			},
+		},
+		Recover: recoverGraphqlError,
+	}
+}
+
+func resolveCategoryConnection(categories []web.CategoryResponse, args map[string]interface{}) (interface{}, error) {
+	first, _ := args["first"].(int)
+	if first < 0 || first > maxCategoryPageSize {
+		return nil, graphql.NewError(graphql.CodeBadUserInput, "first must be between 0 and "+strconv.Itoa(maxCategoryPageSize))
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Id < categories[j].Id
+	})
+	filtered := filterCategories(categories, args["filter"])
+
+	start := 0
+	if after, ok := args["after"].(string); ok {
+		afterId, err := decodeCategoryCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		for start < len(filtered) && filtered[start].Id <= afterId {
+			start++
+		}
+	}
+	end := start + first
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	page := filtered[start:end]
+
+	edges := make([]map[string]interface{}, len(page))
+	for i, category := range page {
+		edges[i] = map[string]interface{}{"cursor": encodeCategoryCursor(category.Id), "node": category}
+	}
+	pageInfo := map[string]interface{}{
+		"hasNextPage":     end < len(filtered),
+		"hasPreviousPage": start > 0,
+	}
+	if len(page) > 0 {
+		pageInfo["startCursor"] = encodeCategoryCursor(page[0].Id)
+		pageInfo["endCursor"] = encodeCategoryCursor(page[len(page)-1].Id)
+	}
+	return map[string]interface{}{
+		"edges":      edges,
+		"nodes":      page,
+		"pageInfo":   pageInfo,
+		"totalCount": len(filtered),
+	}, nil
+}
+
+func filterCategories(categories []web.CategoryResponse, filter interface{}) []web.CategoryResponse {
+	criteria, ok := filter.(map[string]interface{})
+	if !ok {
+		return categories
+	}
+
+	name, _ := criteria["name"].(string)
+	ids := map[string]bool{}
+	idList, hasIds := criteria["ids"].([]interface{})
+	for _, id := range idList {
+		ids[id.(string)] = true
+	}
+
+	filtered := []web.CategoryResponse{}
+	for _, category := range categories {
+		if name != "" && !strings.Contains(strings.ToLower(category.Name), strings.ToLower(name)) {
+			continue
+		}
+		if hasIds && !ids[strconv.Itoa(category.Id)] {
+			continue
+		}
+		filtered = append(filtered, category)
+	}
+	return filtered
+}
+
+func encodeCategoryCursor(categoryId int) string {
+	return base64.StdEncoding.EncodeToString([]byte(categoryCursorPrefix + strconv.Itoa(categoryId)))
+}
+
+func decodeCategoryCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(decoded), categoryCursorPrefix) {
+		categoryId, err := strconv.Atoi(strings.TrimPrefix(string(decoded), categoryCursorPrefix))
+		if err == nil {
+			return categoryId, nil
+		}
+	}
+	return 0, graphql.NewError(graphql.CodeBadUserInput, "after is not a valid cursor")
+}
+
+func graphqlId(value interface{}) (int, error) {
+	categoryId, err := strconv.Atoi(value.(string))
+	if err != nil {
+		return 0, graphql.NewError(graphql.CodeBadUserInput, "id must be an integer")
+	}
+	return categoryId, nil
+}
+
+func authorizeRoles(roles ...string) graphql.AuthorizeFunc {
+	return func(ctx context.Context) error {
+		principal, ok := middleware.PrincipalFromContext(ctx)
+		if !ok {
+			return graphql.NewError(graphql.CodeUnauthenticated, "authentication is required")
+		}
+		for _, role := range roles {
+			if principal.Role == role {
+				return nil
+			}
+		}
+		return graphql.NewError(graphql.CodeForbidden, "role "+principal.Role+" is not allowed")
+	}
+}
+
+func recoverGraphqlError(ctx context.Context, recovered interface{}) error {
+	status, message := exception.ErrorStatus(ctx, recovered)
+	switch status {
+	case http.StatusBadRequest:
+		return graphql.NewError(graphql.CodeBadUserInput, message)
+	case http.StatusUnauthorized:
+		return graphql.NewError(graphql.CodeUnauthenticated, message)
+	case http.StatusForbidden:
+		return graphql.NewError(graphql.CodeForbidden, message)
+	case http.StatusNotFound:
+		return graphql.NewError(graphql.CodeNotFound, message)
+	default:
+		return graphql.NewError(graphql.CodeInternalServerError, message)
+	}
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type HealthController interface {
+	Liveness(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Readiness(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Status(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type HealthControllerImpl struct {
+	HealthService service.HealthService
+}
+
+func NewHealthController(healthService service.HealthService) HealthController {
+	return &HealthControllerImpl{
+		HealthService: healthService,
+	}
+}
+
+func (controller *HealthControllerImpl) Liveness(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *HealthControllerImpl) Readiness(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	readinessResponse := controller.HealthService.Readiness(request.Context())
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   readinessResponse,
+	}
+	if !readinessResponse.Ready {
+		webResponse.Code = http.StatusServiceUnavailable
+		webResponse.Status = "SERVICE UNAVAILABLE"
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(webResponse.Code)
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *HealthControllerImpl) Status(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	statusResponse := controller.HealthService.Status(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   statusResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
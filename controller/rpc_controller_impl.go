@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/jsonrpc"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+	"project-restful-api/tracing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	rpcMaxBatchSize        = 50
+	rpcCodeUnauthorized    = -32001
+	rpcCodeForbidden       = -32003
+	rpcCodeNotFound        = -32004
+	rpcCodeTooManyRequests = -32029
+	rpcCodeServerError     = -32000
+)
+
+type RpcControllerImpl struct {
+	CategoryService service.CategoryService
+	Validate        *validator.Validate
+	Server          *jsonrpc.Server
+}
+
+func NewRpcController(categoryService service.CategoryService, validate *validator.Validate) RpcController {
+	controller := &RpcControllerImpl{
+		CategoryService: categoryService,
+		Validate:        validate,
+		Server:          jsonrpc.NewServer(rpcMaxBatchSize),
+	}
+	controller.Server.Recover = recoverRpcError
+	controller.Server.Register("category.create", []string{"name"}, controller.createCategory)
+	controller.Server.Register("category.update", []string{"id", "name"}, controller.updateCategory)
+	controller.Server.Register("category.delete", []string{"id"}, controller.deleteCategory)
+	controller.Server.Register("category.get", []string{"id"}, controller.getCategory)
+	controller.Server.Register("category.list", nil, controller.listCategories)
+	return controller
+}
+
+func (controller *RpcControllerImpl) Call(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "RpcController.Call")
+	defer span.End()
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			panic(helper.NewRequestBodyTooLargeError(maxBytesError.Limit))
+		}
+		panic(err)
+	}
+
+	response, ok := controller.Server.Handle(ctx, body)
+	if !ok {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	helper.WriteToResponseBody(writer, response)
+}
+
+func (controller *RpcControllerImpl) createCategory(ctx context.Context, params jsonrpc.Params) (interface{}, error) {
+	categoryCreateRequest := web.CategoryCreateRequest{}
+	if err := controller.decode(params, &categoryCreateRequest); err != nil {
+		return nil, err
+	}
+	return controller.CategoryService.Create(ctx, categoryCreateRequest), nil
+}
+
+func (controller *RpcControllerImpl) updateCategory(ctx context.Context, params jsonrpc.Params) (interface{}, error) {
+	categoryUpdateRequest := web.CategoryUpdateRequest{}
+	if err := controller.decode(params, &categoryUpdateRequest); err != nil {
+		return nil, err
+	}
+	return controller.CategoryService.Update(ctx, categoryUpdateRequest), nil
+}
+
+func (controller *RpcControllerImpl) deleteCategory(ctx context.Context, params jsonrpc.Params) (interface{}, error) {
+	categoryIdRequest := web.CategoryIdRequest{}
+	if err := controller.decode(params, &categoryIdRequest); err != nil {
+		return nil, err
+	}
+	controller.CategoryService.Delete(ctx, categoryIdRequest.Id)
+	return nil, nil
+}
+
+func (controller *RpcControllerImpl) getCategory(ctx context.Context, params jsonrpc.Params) (interface{}, error) {
+	categoryIdRequest := web.CategoryIdRequest{}
+	if err := controller.decode(params, &categoryIdRequest); err != nil {
+		return nil, err
+	}
+	return controller.CategoryService.FindById(ctx, categoryIdRequest.Id), nil
+}
+
+func (controller *RpcControllerImpl) listCategories(ctx context.Context, params jsonrpc.Params) (interface{}, error) {
+	categoryResponses := controller.CategoryService.FindAll(ctx)
+	if categoryResponses == nil {
+		categoryResponses = []web.CategoryResponse{}
+	}
+	return categoryResponses, nil
+}
+
+func (controller *RpcControllerImpl) decode(params jsonrpc.Params, result interface{}) error {
+	if err := params.Decode(result); err != nil {
+		return err
+	}
+	err := controller.Validate.Struct(result)
+	helper.PanicIfError(err)
+	return nil
+}
+
+func recoverRpcError(ctx context.Context, recovered interface{}) *jsonrpc.Error {
+	status, message := exception.ErrorStatus(ctx, recovered)
+	switch status {
+	case http.StatusBadRequest:
+		return jsonrpc.NewError(jsonrpc.CodeInvalidParams, "Invalid params", message)
+	case http.StatusUnauthorized:
+		return jsonrpc.NewError(rpcCodeUnauthorized, "Unauthorized", message)
+	case http.StatusForbidden:
+		return jsonrpc.NewError(rpcCodeForbidden, "Forbidden", message)
+	case http.StatusNotFound:
+		return jsonrpc.NewError(rpcCodeNotFound, "Not found", message)
+	case http.StatusTooManyRequests:
+		return jsonrpc.NewError(rpcCodeTooManyRequests, "Too many requests", message)
+	case http.StatusInternalServerError:
+		return jsonrpc.NewError(jsonrpc.CodeInternalError, "Internal error", web.ErrorResponse{RequestId: helper.RequestId(ctx)})
+	default:
+		return jsonrpc.NewError(rpcCodeServerError, http.StatusText(status), message)
+	}
+}
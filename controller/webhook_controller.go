@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WebhookController interface {
+	Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Deliveries(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Redeliver(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
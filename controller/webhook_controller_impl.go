@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+	"project-restful-api/tracing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+)
+
+type WebhookControllerImpl struct {
+	WebhookService service.WebhookService
+	Validate       *validator.Validate
+}
+
+func NewWebhookController(webhookService service.WebhookService, validate *validator.Validate) WebhookController {
+	return &WebhookControllerImpl{
+		WebhookService: webhookService,
+		Validate:       validate,
+	}
+}
+
+func (controller *WebhookControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.Create")
+	defer span.End()
+
+	webhookCreateRequest := web.WebhookCreateRequest{}
+	helper.Bind(request, params, &webhookCreateRequest, controller.Validate)
+
+	webhookResponse := controller.WebhookService.Create(ctx, webhookCreateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.Update")
+	defer span.End()
+
+	webhookUpdateRequest := web.WebhookUpdateRequest{}
+	helper.Bind(request, params, &webhookUpdateRequest, controller.Validate)
+
+	webhookResponse := controller.WebhookService.Update(ctx, webhookUpdateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.Delete")
+	defer span.End()
+
+	webhookIdRequest := web.WebhookIdRequest{}
+	helper.Bind(request, params, &webhookIdRequest, controller.Validate)
+
+	controller.WebhookService.Delete(ctx, webhookIdRequest.Id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.FindById")
+	defer span.End()
+
+	webhookIdRequest := web.WebhookIdRequest{}
+	helper.Bind(request, params, &webhookIdRequest, controller.Validate)
+
+	webhookResponse := controller.WebhookService.FindById(ctx, webhookIdRequest.Id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.FindAll")
+	defer span.End()
+
+	webhookResponses := controller.WebhookService.FindAll(ctx)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Deliveries(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.Deliveries")
+	defer span.End()
+
+	webhookDeliveryQuery := web.WebhookDeliveryQuery{Limit: 50}
+	helper.Bind(request, params, &webhookDeliveryQuery, controller.Validate)
+
+	deliveryResponses := controller.WebhookService.FindDeliveries(ctx, webhookDeliveryQuery)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveryResponses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Redeliver(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx, span := tracing.Start(request.Context(), "WebhookController.Redeliver")
+	defer span.End()
+
+	webhookDeliveryIdRequest := web.WebhookDeliveryIdRequest{}
+	helper.Bind(request, params, &webhookDeliveryIdRequest, controller.Validate)
+
+	deliveryResponse := controller.WebhookService.Redeliver(ctx, webhookDeliveryIdRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveryResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
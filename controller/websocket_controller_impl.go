@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"project-restful-api/event"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/service"
+	"project-restful-api/tracing"
+	"project-restful-api/websocket"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WebSocketControllerImpl struct {
+	CategoryService service.CategoryService
+	Broker          *event.Broker
+	Config          websocket.Config
+}
+
+func NewWebSocketController(categoryService service.CategoryService, broker *event.Broker, config websocket.Config) WebSocketController {
+	return &WebSocketControllerImpl{
+		CategoryService: categoryService,
+		Broker:          broker,
+		Config:          config,
+	}
+}
+
+func (controller *WebSocketControllerImpl) Connect(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	conn, err := websocket.Upgrade(writer, request, controller.Config)
+	if err != nil {
+		var handshakeError websocket.HandshakeError
+		if !errors.As(err, &handshakeError) {
+			panic(err)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(handshakeError.Status)
+		helper.WriteToResponseBody(writer, web.WebResponse{
+			Code:   handshakeError.Status,
+			Status: strings.ToUpper(http.StatusText(handshakeError.Status)),
+			Data:   handshakeError.Message,
+		})
+		return
+	}
+	defer conn.Close(websocket.CloseNormal, "")
+
+	session := &webSocketSession{
+		ctx:        request.Context(),
+		controller: controller,
+		conn:       conn,
+		categories: map[int]bool{},
+	}
+	subscription, _ := controller.Broker.Subscribe(domain.CategoryEventTypes, 0)
+	defer subscription.Close()
+	go session.forward(subscription)
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		session.handle(payload)
+	}
+}
+
+type webSocketSession struct {
+	ctx        context.Context
+	controller *WebSocketControllerImpl
+	conn       *websocket.Conn
+	mutex      sync.Mutex
+	all        bool
+	categories map[int]bool
+}
+
+func (session *webSocketSession) forward(subscription *event.Subscription) {
+	for {
+		select {
+		case <-session.conn.Done():
+			return
+		case published, ok := <-subscription.Events:
+			if !ok {
+				if subscription.Overflowed {
+					session.conn.Close(websocket.CloseTryAgainLater, "client is not keeping up")
+				} else {
+					session.conn.Close(websocket.CloseGoingAway, "server is shutting down")
+				}
+				return
+			}
+			category, _ := published.Data.(web.CategoryResponse)
+			if session.subscribed(category.Id) {
+				session.send(web.WebSocketMessage{Type: "event", Event: published.Type, EventId: published.Id, Data: published.Data})
+			}
+		}
+	}
+}
+
+func (session *webSocketSession) handle(payload []byte) {
+	request := web.WebSocketRequest{}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			session.sendError(request.Id, recovered)
+		}
+	}()
+
+	decodeWebSocketData(payload, "message", &request)
+	ctx, span := tracing.Start(session.ctx, "WebSocketController.Handle")
+	defer span.End()
+	span.SetAttribute("websocket.message.type", request.Type)
+
+	switch request.Type {
+	case "subscribe":
+		session.subscribe(request.Categories)
+		session.send(web.WebSocketMessage{Type: "ack", Id: request.Id, Data: session.subscription()})
+	case "unsubscribe":
+		session.unsubscribe(request.Categories)
+		session.send(web.WebSocketMessage{Type: "ack", Id: request.Id, Data: session.subscription()})
+	case "create":
+		categoryCreateRequest := web.CategoryCreateRequest{}
+		decodeWebSocketData(request.Data, "data", &categoryCreateRequest)
+		categoryResponse := session.controller.CategoryService.Create(ctx, categoryCreateRequest)
+		session.send(web.WebSocketMessage{Type: "ack", Id: request.Id, Data: categoryResponse})
+	case "update":
+		categoryUpdateRequest := web.CategoryUpdateRequest{}
+		decodeWebSocketData(request.Data, "data", &categoryUpdateRequest)
+		categoryResponse := session.controller.CategoryService.Update(ctx, categoryUpdateRequest)
+		session.send(web.WebSocketMessage{Type: "ack", Id: request.Id, Data: categoryResponse})
+	case "delete":
+		categoryIdRequest := web.CategoryIdRequest{}
+		decodeWebSocketData(request.Data, "data", &categoryIdRequest)
+		session.controller.CategoryService.Delete(ctx, categoryIdRequest.Id)
+		session.send(web.WebSocketMessage{Type: "ack", Id: request.Id})
+	default:
+		panic(helper.BindError{Source: "message", Field: "type", Message: "must be one of subscribe, unsubscribe, create, update, delete"})
+	}
+}
+
+func (session *webSocketSession) subscribe(categories []int) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if len(categories) == 0 {
+		session.all = true
+	}
+	for _, categoryId := range categories {
+		session.categories[categoryId] = true
+	}
+}
+
+func (session *webSocketSession) unsubscribe(categories []int) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if len(categories) == 0 {
+		session.all = false
+		session.categories = map[int]bool{}
+	}
+	for _, categoryId := range categories {
+		delete(session.categories, categoryId)
+	}
+}
+
+func (session *webSocketSession) subscribed(categoryId int) bool {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return session.all || session.categories[categoryId]
+}
+
+func (session *webSocketSession) subscription() web.WebSocketSubscription {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	subscription := web.WebSocketSubscription{All: session.all, Categories: []int{}}
+	for categoryId := range session.categories {
+		subscription.Categories = append(subscription.Categories, categoryId)
+	}
+	sort.Ints(subscription.Categories)
+	return subscription
+}
+
+func (session *webSocketSession) send(message web.WebSocketMessage) {
+	payload, err := json.Marshal(message)
+	helper.PanicIfError(err)
+	session.conn.Send(websocket.TextMessage, payload)
+}
+
+func (session *webSocketSession) sendError(id string, recovered interface{}) {
+	status, message := exception.ErrorStatus(session.ctx, recovered)
+	response := web.WebResponse{
+		Code:   status,
+		Status: strings.ToUpper(http.StatusText(status)),
+		Data:   message,
+	}
+	if status == http.StatusInternalServerError {
+		response.Data = web.ErrorResponse{RequestId: helper.RequestId(session.ctx)}
+	}
+	session.send(web.WebSocketMessage{Type: "error", Id: id, Data: response})
+}
+
+func decodeWebSocketData(data []byte, field string, target interface{}) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		panic(helper.BindError{Source: "message", Field: field, Message: "is not valid: " + err.Error()})
+	}
+}
@@ -0,0 +1,136 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+type Event struct {
+	Id   uint64
+	Type string
+	Data interface{}
+	Time time.Time
+}
+
+type Publisher interface {
+	Publish(eventType string, data interface{}) Event
+}
+
+type BrokerConfig struct {
+	ReplaySize       int
+	SubscriberBuffer int
+}
+
+type Broker struct {
+	Config      BrokerConfig
+	mutex       sync.Mutex
+	lastId      uint64
+	replay      []Event
+	subscribers map[*Subscription]bool
+	closed      bool
+}
+
+type Subscription struct {
+	Events     <-chan Event
+	Overflowed bool
+	events     chan Event
+	types      map[string]bool
+	broker     *Broker
+}
+
+func NewBroker(config BrokerConfig) *Broker {
+	return &Broker{
+		Config:      config,
+		subscribers: map[*Subscription]bool{},
+	}
+}
+
+func (broker *Broker) Publish(eventType string, data interface{}) Event {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.lastId++
+	event := Event{Id: broker.lastId, Type: eventType, Data: data, Time: time.Now()}
+	if broker.Config.ReplaySize > 0 {
+		if len(broker.replay) == broker.Config.ReplaySize {
+			broker.replay = append(broker.replay[:0], broker.replay[1:]...)
+		}
+		broker.replay = append(broker.replay, event)
+	}
+
+	for subscription := range broker.subscribers {
+		if !subscription.accepts(event.Type) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.Overflowed = true
+			broker.remove(subscription)
+		}
+	}
+	return event
+}
+
+func (broker *Broker) Subscribe(types []string, lastEventId uint64) (*Subscription, []Event) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	subscription := &Subscription{
+		events: make(chan Event, broker.Config.SubscriberBuffer),
+		types:  map[string]bool{},
+		broker: broker,
+	}
+	subscription.Events = subscription.events
+	for _, eventType := range types {
+		subscription.types[eventType] = true
+	}
+	if broker.closed {
+		close(subscription.events)
+		return subscription, nil
+	}
+	broker.subscribers[subscription] = true
+
+	var replay []Event
+	if lastEventId > 0 {
+		for _, event := range broker.replay {
+			if event.Id > lastEventId && subscription.accepts(event.Type) {
+				replay = append(replay, event)
+			}
+		}
+	}
+	return subscription, replay
+}
+
+func (broker *Broker) LastId() uint64 {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	return broker.lastId
+}
+
+func (broker *Broker) Close() {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.closed = true
+	for subscription := range broker.subscribers {
+		broker.remove(subscription)
+	}
+}
+
+func (broker *Broker) remove(subscription *Subscription) {
+	if broker.subscribers[subscription] {
+		delete(broker.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+func (subscription *Subscription) Close() {
+	subscription.broker.mutex.Lock()
+	defer subscription.broker.mutex.Unlock()
+	subscription.broker.remove(subscription)
+}
+
+func (subscription *Subscription) accepts(eventType string) bool {
+	return len(subscription.types) == 0 || subscription.types[eventType]
+}
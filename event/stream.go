@@ -0,0 +1,52 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const StreamContentType = "text/event-stream"
+
+type StreamConfig struct {
+	Heartbeat   time.Duration
+	Retry       time.Duration
+	MaxDuration time.Duration
+}
+
+func ParseLastEventId(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func WriteServerSentEvent(writer io.Writer, event Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	builder.WriteString("id: " + strconv.FormatUint(event.Id, 10) + "\n")
+	builder.WriteString("event: " + event.Type + "\n")
+	for _, line := range strings.Split(string(data), "\n") {
+		builder.WriteString("data: " + line + "\n")
+	}
+	builder.WriteString("\n")
+	_, err = io.WriteString(writer, builder.String())
+	return err
+}
+
+func WriteRetry(writer io.Writer, retry time.Duration) error {
+	_, err := fmt.Fprintf(writer, "retry: %d\n\n", retry.Milliseconds())
+	return err
+}
+
+func WriteComment(writer io.Writer, comment string) error {
+	_, err := io.WriteString(writer, ": "+comment+"\n\n")
+	return err
+}
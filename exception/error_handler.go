@@ -4,63 +4,23 @@ import (
 	"net/http"
 	"project-restful-api/helper"
 	"project-restful-api/model/web"
-
-	"github.com/go-playground/validator/v10"
+	"strings"
 )
 
 func ErrorHandler(writer http.ResponseWriter, request *http.Request, err interface{}) {
-	if notFoundError(writer, request, err) {
-		return
-	}
-
-	if validationErrors(writer, request, err) {
-		return
-	}
-
-	internalServerError(writer, request, err)
-}
+	status, message := ErrorStatus(request.Context(), err)
 
-func validationErrors(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
-	exception, ok := err.(validator.ValidationErrors)
-	if ok {
-		writer.Header().Set("Content-Type", "application/json")
-		writer.WriteHeader(http.StatusBadRequest)
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   exception.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
-		return true
-	} else {
-		return false
+	var data interface{} = message
+	if status == http.StatusInternalServerError {
+		data = web.ErrorResponse{RequestId: helper.RequestId(request.Context())}
 	}
-}
-
-func notFoundError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
-	exception, ok := err.(NotFoundError)
-	if ok {
-		writer.Header().Set("Content-Type", "application/json")
-		writer.WriteHeader(http.StatusNotFound)
-		webResponse := web.WebResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   exception.Error,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
-		return true
-	} else {
-		return false
-	}
-}
 
-func internalServerError(writer http.ResponseWriter, request *http.Request, err interface{}) {
 	writer.Header().Set("Content-Type", "application/json")
-	writer.WriteHeader(http.StatusInternalServerError)
+	writer.WriteHeader(status)
 	webResponse := web.WebResponse{
-		Code:   http.StatusInternalServerError,
-		Status: "INTERNAL SERVER ERROR",
-		Data:   err,
+		Code:   status,
+		Status: strings.ToUpper(http.StatusText(status)),
+		Data:   data,
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -0,0 +1,47 @@
+package exception
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/metrics"
+	"runtime/debug"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrorStatus maps a recovered panic value to the status and message sent to
+// the client. Unexpected values are logged and counted as 500s.
+func ErrorStatus(ctx context.Context, err interface{}) (int, string) {
+	switch exception := err.(type) {
+	case NotFoundError:
+		return http.StatusNotFound, exception.Error
+	case validator.ValidationErrors:
+		return http.StatusBadRequest, exception.Error()
+	case UnauthorizedError:
+		return http.StatusUnauthorized, exception.Error
+	case ForbiddenError:
+		return http.StatusForbidden, exception.Error
+	case ConflictError:
+		return http.StatusConflict, exception.Error
+	case TooManyRequestsError:
+		return http.StatusTooManyRequests, exception.Error
+	case helper.RequestBodyError:
+		return exception.Status, exception.Message
+	case helper.BindError:
+		return http.StatusBadRequest, exception.Error()
+	}
+
+	metrics.PanicsTotal.Inc(fmt.Sprintf("%T", err))
+	info := helper.RequestInfoFromContext(ctx)
+	helper.LogJSON(map[string]interface{}{
+		"level":      "error",
+		"message":    "unhandled panic",
+		"request_id": info.RequestId,
+		"route":      info.Route,
+		"error":      fmt.Sprint(err),
+		"stack":      string(debug.Stack()),
+	})
+	return http.StatusInternalServerError, "internal server error"
+}
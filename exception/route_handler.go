@@ -0,0 +1,41 @@
+package exception
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"strings"
+)
+
+func NotFoundHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusNotFound)
+	webResponse := web.WebResponse{
+		Code:   http.StatusNotFound,
+		Status: "NOT FOUND",
+		Data:   "route " + request.URL.Path + " is not found",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func MethodNotAllowedHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusMethodNotAllowed)
+	webResponse := web.WebResponse{
+		Code:   http.StatusMethodNotAllowed,
+		Status: "METHOD NOT ALLOWED",
+		Data:   "method " + request.Method + " is not allowed, use one of " + writer.Header().Get("Allow"),
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func OptionsHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   strings.Split(writer.Header().Get("Allow"), ", "),
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,9 @@
+package exception
+
+type TooManyRequestsError struct {
+	Error string
+}
+
+func NewTooManyRequestsError(error string) TooManyRequestsError {
+	return TooManyRequestsError{Error: error}
+}
@@ -0,0 +1,131 @@
+package graphql
+
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet []Selection
+	Location     Location
+}
+
+type VariableDefinition struct {
+	Name     string
+	Type     *TypeReference
+	Default  Value
+	Location Location
+}
+
+type TypeReference struct {
+	Name    string
+	Elem    *TypeReference
+	NonNull bool
+}
+
+type Selection interface {
+	selectionLocation() Location
+}
+
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Location     Location
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Location   Location
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+type Argument struct {
+	Name     string
+	Value    Value
+	Location Location
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Location  Location
+}
+
+type Value interface{}
+
+type Variable struct {
+	Name string
+}
+
+type IntValue string
+
+type FloatValue string
+
+type StringValue string
+
+type BooleanValue bool
+
+type NullValue struct{}
+
+type EnumValue string
+
+type ListValue []Value
+
+type ObjectValue []*ObjectField
+
+type ObjectField struct {
+	Name  string
+	Value Value
+}
+
+func (field *Field) ResponseKey() string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+	return field.Name
+}
+
+func (field *Field) selectionLocation() Location {
+	return field.Location
+}
+
+func (spread *FragmentSpread) selectionLocation() Location {
+	return spread.Location
+}
+
+func (fragment *InlineFragment) selectionLocation() Location {
+	return fragment.Location
+}
+
+func (reference *TypeReference) String() string {
+	name := reference.Name
+	if reference.Elem != nil {
+		name = "[" + reference.Elem.String() + "]"
+	}
+	if reference.NonNull {
+		name += "!"
+	}
+	return name
+}
@@ -0,0 +1,48 @@
+package graphql
+
+import "fmt"
+
+const (
+	CodeGraphqlParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeGraphqlValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput            = "BAD_USER_INPUT"
+	CodeUnauthenticated         = "UNAUTHENTICATED"
+	CodeForbidden               = "FORBIDDEN"
+	CodeNotFound                = "NOT_FOUND"
+	CodeInternalServerError     = "INTERNAL_SERVER_ERROR"
+)
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func NewError(code string, message string) *Error {
+	return &Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func syntaxError(location Location, message string) *Error {
+	err := NewError(CodeGraphqlParseFailed, fmt.Sprintf("Syntax Error: %s", message))
+	err.Locations = []Location{location}
+	return err
+}
+
+func validationError(location Location, format string, arguments ...interface{}) *Error {
+	err := NewError(CodeGraphqlValidationFailed, fmt.Sprintf(format, arguments...))
+	err.Locations = []Location{location}
+	return err
+}
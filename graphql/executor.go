@@ -0,0 +1,486 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Response struct {
+	Data     interface{}
+	Errors   []*Error
+	Executed bool
+}
+
+func (response *Response) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Data   interface{} `json:"data"`
+		Errors []*Error    `json:"errors,omitempty"`
+	}{Data: response.Data, Errors: response.Errors}
+	if !response.Executed {
+		return json.Marshal(struct {
+			Errors []*Error `json:"errors"`
+		}{Errors: response.Errors})
+	}
+	return json.Marshal(body)
+}
+
+var errNullValue = errors.New("graphql: null value propagated")
+
+type fieldGroups struct {
+	keys   []string
+	fields map[string][]*Field
+}
+
+type execution struct {
+	ctx           context.Context
+	schema        *Schema
+	document      *Document
+	limits        Limits
+	variables     map[string]interface{}
+	arguments     map[*Field]map[string]interface{}
+	depthExceeded bool
+	errors        []*Error
+}
+
+func (schema *Schema) Execute(ctx context.Context, request Request, limits Limits) *Response {
+	document, err := Parse(request.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	operation, err := selectOperation(document, request.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	var root *Object
+	switch operation.Type {
+	case "query":
+		root = schema.Query
+	case "mutation":
+		root = schema.Mutation
+	}
+	if root == nil {
+		return &Response{Errors: []*Error{validationError(operation.Location, "Schema does not support %s operations.", operation.Type)}}
+	}
+
+	execution := &execution{
+		ctx:       ctx,
+		schema:    schema,
+		document:  document,
+		limits:    limits,
+		variables: map[string]interface{}{},
+		arguments: map[*Field]map[string]interface{}{},
+	}
+	execution.coerceVariables(operation, request.Variables)
+	if len(execution.errors) > 0 {
+		return &Response{Errors: execution.errors}
+	}
+
+	complexity := execution.analyze(root, operation.SelectionSet, 1)
+	if len(execution.errors) > 0 {
+		return &Response{Errors: execution.errors}
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return &Response{Errors: []*Error{validationError(operation.Location, "Query complexity %d exceeds the maximum allowed complexity of %d.", complexity, limits.MaxComplexity)}}
+	}
+
+	data, err := execution.executeSelectionSet(root, nil, operation.SelectionSet, nil)
+	response := &Response{Errors: execution.errors, Executed: true}
+	if err == nil {
+		response.Data = data
+	}
+	return response
+}
+
+func selectOperation(document *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(document.Operations) > 1 {
+			return nil, NewError(CodeBadUserInput, "Must provide operation name if query contains multiple operations.")
+		}
+		return document.Operations[0], nil
+	}
+	for _, operation := range document.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return nil, NewError(CodeBadUserInput, fmt.Sprintf("Unknown operation named %q.", name))
+}
+
+func (execution *execution) coerceVariables(operation *Operation, provided map[string]interface{}) {
+	types := execution.schema.inputTypes()
+	for _, definition := range operation.Variables {
+		variableType, ok := execution.schema.resolveTypeReference(definition.Type, types)
+		if !ok {
+			execution.errors = append(execution.errors, validationError(definition.Location, "Unknown type %q.", definition.Type.String()))
+			continue
+		}
+
+		value, ok := provided[definition.Name]
+		if !ok {
+			if definition.Default != nil {
+				coerced, err := coerceLiteral(definition.Default, variableType, nil)
+				if err != nil {
+					execution.errors = append(execution.errors, validationError(definition.Location, "Variable \"$%s\" has invalid default value: %s.", definition.Name, err))
+					continue
+				}
+				execution.variables[definition.Name] = coerced
+			} else if _, nonNull := variableType.(*NonNull); nonNull {
+				execution.errors = append(execution.errors, variableError(definition, "Variable \"$%s\" of required type %q was not provided.", definition.Name, variableType.String()))
+			}
+			continue
+		}
+
+		coerced, err := coerceVariableValue(value, variableType)
+		if err != nil {
+			execution.errors = append(execution.errors, variableError(definition, "Variable \"$%s\" got invalid value: %s.", definition.Name, err))
+			continue
+		}
+		execution.variables[definition.Name] = coerced
+	}
+}
+
+func variableError(definition *VariableDefinition, format string, arguments ...interface{}) *Error {
+	err := NewError(CodeBadUserInput, fmt.Sprintf(format, arguments...))
+	err.Locations = []Location{definition.Location}
+	return err
+}
+
+func (execution *execution) analyze(objectType *Object, selections []Selection, depth int) int {
+	if execution.limits.MaxDepth > 0 && depth > execution.limits.MaxDepth {
+		if !execution.depthExceeded {
+			execution.depthExceeded = true
+			execution.errors = append(execution.errors, validationError(selections[0].selectionLocation(), "Query depth exceeds the maximum allowed depth of %d.", execution.limits.MaxDepth))
+		}
+		return 0
+	}
+
+	groups, err := execution.collectFields(objectType, selections, map[string]bool{})
+	if err != nil {
+		execution.errors = append(execution.errors, toError(err))
+		return 0
+	}
+
+	total := 0
+	for _, key := range groups.keys {
+		fields := groups.fields[key]
+		field := fields[0]
+		if field.Name == "__typename" {
+			total++
+			continue
+		}
+
+		definition, ok := objectType.Fields[field.Name]
+		if !ok {
+			execution.errors = append(execution.errors, validationError(field.Location, "Cannot query field %q on type %q.", field.Name, objectType.Name))
+			continue
+		}
+		arguments, err := execution.coerceArguments(objectType, definition, field)
+		if err != nil {
+			execution.errors = append(execution.errors, err)
+			continue
+		}
+		execution.arguments[field] = arguments
+
+		childComplexity := 0
+		subselections := mergeSelectionSets(fields)
+		switch named := namedType(definition.Type).(type) {
+		case *Object:
+			if len(subselections) == 0 {
+				execution.errors = append(execution.errors, validationError(field.Location, "Field %q of type %q must have a selection of subfields.", field.Name, definition.Type.String()))
+				continue
+			}
+			childComplexity = execution.analyze(named, subselections, depth+1)
+		default:
+			if len(subselections) > 0 {
+				execution.errors = append(execution.errors, validationError(field.Location, "Field %q must not have a selection since type %q has no subfields.", field.Name, definition.Type.String()))
+				continue
+			}
+		}
+
+		if definition.Complexity != nil {
+			total += definition.Complexity(arguments, childComplexity)
+		} else {
+			total += 1 + childComplexity
+		}
+	}
+	return total
+}
+
+func (execution *execution) coerceArguments(objectType *Object, definition *FieldDefinition, field *Field) (map[string]interface{}, *Error) {
+	provided := map[string]*Argument{}
+	for _, argument := range field.Arguments {
+		if definition.Argument(argument.Name) == nil {
+			return nil, validationError(argument.Location, "Unknown argument %q on field \"%s.%s\".", argument.Name, objectType.Name, field.Name)
+		}
+		provided[argument.Name] = argument
+	}
+
+	arguments := map[string]interface{}{}
+	for _, argumentDefinition := range definition.Args {
+		argument, ok := provided[argumentDefinition.Name]
+		if ok {
+			if variable, isVariable := argument.Value.(Variable); isVariable {
+				_, ok = execution.variables[variable.Name]
+			}
+		}
+		_, nonNull := argumentDefinition.Type.(*NonNull)
+		if !ok {
+			if argumentDefinition.Default != nil {
+				arguments[argumentDefinition.Name] = argumentDefinition.Default
+			} else if nonNull {
+				return nil, validationError(field.Location, "Field %q argument %q of type %q is required, but it was not provided.", field.Name, argumentDefinition.Name, argumentDefinition.Type.String())
+			}
+			continue
+		}
+
+		value, err := coerceLiteral(argument.Value, argumentDefinition.Type, execution.variables)
+		if err == nil && value == nil && nonNull {
+			err = fmt.Errorf("expected value of type %s, found null", argumentDefinition.Type)
+		}
+		if err != nil {
+			return nil, validationError(argument.Location, "Argument %q has invalid value: %s.", argument.Name, err)
+		}
+		arguments[argumentDefinition.Name] = value
+	}
+	return arguments, nil
+}
+
+func (execution *execution) collectFields(objectType *Object, selections []Selection, visited map[string]bool) (*fieldGroups, error) {
+	groups := &fieldGroups{fields: map[string][]*Field{}}
+	var collect func(selections []Selection) error
+	collect = func(selections []Selection) error {
+		for _, selection := range selections {
+			switch selection := selection.(type) {
+			case *Field:
+				if !execution.shouldInclude(selection.Directives) {
+					continue
+				}
+				key := selection.ResponseKey()
+				if _, ok := groups.fields[key]; !ok {
+					groups.keys = append(groups.keys, key)
+				}
+				groups.fields[key] = append(groups.fields[key], selection)
+			case *FragmentSpread:
+				if !execution.shouldInclude(selection.Directives) || visited[selection.Name] {
+					continue
+				}
+				visited[selection.Name] = true
+				fragment, ok := execution.document.Fragments[selection.Name]
+				if !ok {
+					return validationError(selection.Location, "Unknown fragment %q.", selection.Name)
+				}
+				if fragment.TypeCondition != objectType.Name {
+					continue
+				}
+				if err := collect(fragment.SelectionSet); err != nil {
+					return err
+				}
+			case *InlineFragment:
+				if !execution.shouldInclude(selection.Directives) {
+					continue
+				}
+				if selection.TypeCondition != "" && selection.TypeCondition != objectType.Name {
+					continue
+				}
+				if err := collect(selection.SelectionSet); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return groups, collect(selections)
+}
+
+func (execution *execution) shouldInclude(directives []*Directive) bool {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			continue
+		}
+		for _, argument := range directive.Arguments {
+			if argument.Name != "if" {
+				continue
+			}
+			value, err := coerceLiteral(argument.Value, &NonNull{Of: Boolean}, execution.variables)
+			if err != nil {
+				continue
+			}
+			if value.(bool) == (directive.Name == "skip") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func mergeSelectionSets(fields []*Field) []Selection {
+	var selections []Selection
+	for _, field := range fields {
+		selections = append(selections, field.SelectionSet...)
+	}
+	return selections
+}
+
+func (execution *execution) executeSelectionSet(objectType *Object, source interface{}, selections []Selection, path []interface{}) (interface{}, error) {
+	groups, err := execution.collectFields(objectType, selections, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := newResultMap()
+	for _, key := range groups.keys {
+		fields := groups.fields[key]
+		if fields[0].Name == "__typename" {
+			result.Set(key, objectType.Name)
+			continue
+		}
+
+		definition := objectType.Fields[fields[0].Name]
+		fieldPath := append(append([]interface{}{}, path...), key)
+		value, err := execution.executeField(definition, source, fields, fieldPath)
+		if err != nil {
+			if _, nonNull := definition.Type.(*NonNull); nonNull {
+				return nil, err
+			}
+			value = nil
+		}
+		result.Set(key, value)
+	}
+	return result, nil
+}
+
+func (execution *execution) executeField(definition *FieldDefinition, source interface{}, fields []*Field, path []interface{}) (interface{}, error) {
+	field := fields[0]
+	if definition.Authorize != nil {
+		if err := definition.Authorize(execution.ctx); err != nil {
+			execution.addError(err, field, path)
+			return nil, errNullValue
+		}
+	}
+
+	value, err := execution.resolve(definition, source, field)
+	if err != nil {
+		execution.addError(err, field, path)
+		return nil, errNullValue
+	}
+	return execution.completeValue(definition.Type, fields, value, path)
+}
+
+func (execution *execution) resolve(definition *FieldDefinition, source interface{}, field *Field) (value interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if execution.schema.Recover != nil {
+				err = execution.schema.Recover(execution.ctx, recovered)
+			} else {
+				err = NewError(CodeInternalServerError, "internal server error")
+			}
+		}
+	}()
+
+	params := ResolveParams{
+		Context: execution.ctx,
+		Source:  source,
+		Args:    execution.arguments[field],
+	}
+	if definition.Resolve == nil {
+		return defaultResolve(params, field.Name)
+	}
+	return definition.Resolve(params)
+}
+
+func (execution *execution) completeValue(fieldType Type, fields []*Field, value interface{}, path []interface{}) (interface{}, error) {
+	if nonNull, ok := fieldType.(*NonNull); ok {
+		completed, err := execution.completeValue(nonNull.Of, fields, value, path)
+		if err != nil {
+			return nil, err
+		}
+		if completed == nil {
+			execution.addError(fmt.Errorf("Cannot return null for non-nullable field %q.", fields[0].Name), fields[0], path)
+			return nil, errNullValue
+		}
+		return completed, nil
+	}
+	if isNil(value) {
+		return nil, nil
+	}
+
+	switch fieldType := fieldType.(type) {
+	case *Scalar:
+		serialized, err := fieldType.Serialize(value)
+		if err != nil {
+			execution.addError(err, fields[0], path)
+			return nil, errNullValue
+		}
+		return serialized, nil
+	case *List:
+		list := reflect.ValueOf(value)
+		if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+			execution.addError(fmt.Errorf("Expected a list for field %q.", fields[0].Name), fields[0], path)
+			return nil, errNullValue
+		}
+		items := make([]interface{}, list.Len())
+		_, itemNonNull := fieldType.Of.(*NonNull)
+		for i := range items {
+			itemPath := append(append([]interface{}{}, path...), i)
+			item, err := execution.completeValue(fieldType.Of, fields, list.Index(i).Interface(), itemPath)
+			if err != nil {
+				if itemNonNull {
+					return nil, err
+				}
+				item = nil
+			}
+			items[i] = item
+		}
+		return items, nil
+	case *Object:
+		return execution.executeSelectionSet(fieldType, value, mergeSelectionSets(fields), path)
+	default:
+		execution.addError(fmt.Errorf("Field %q has an unsupported type %s.", fields[0].Name, fieldType), fields[0], path)
+		return nil, errNullValue
+	}
+}
+
+func (execution *execution) addError(err error, field *Field, path []interface{}) {
+	graphqlError := toError(err)
+	graphqlError.Locations = []Location{field.Location}
+	graphqlError.Path = path
+	execution.errors = append(execution.errors, graphqlError)
+}
+
+func toError(err error) *Error {
+	var graphqlError *Error
+	if errors.As(err, &graphqlError) {
+		copied := *graphqlError
+		return &copied
+	}
+	return &Error{Message: err.Error()}
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return reflected.IsNil()
+	default:
+		return false
+	}
+}
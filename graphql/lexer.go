@@ -0,0 +1,245 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	Kind   tokenKind
+	Value  string
+	Line   int
+	Column int
+}
+
+type lexer struct {
+	source    string
+	position  int
+	line      int
+	lineStart int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source, line: 1}
+}
+
+func (lexer *lexer) location() Location {
+	return Location{Line: lexer.line, Column: lexer.position - lexer.lineStart + 1}
+}
+
+func (lexer *lexer) next() (token, error) {
+	lexer.skipIgnored()
+	location := lexer.location()
+	result := token{Line: location.Line, Column: location.Column}
+	if lexer.position >= len(lexer.source) {
+		result.Kind = tokenEOF
+		return result, nil
+	}
+
+	char := lexer.source[lexer.position]
+	switch {
+	case strings.IndexByte("!$&()[]{}:=@|", char) >= 0:
+		lexer.position++
+		result.Kind = tokenPunctuator
+		result.Value = string(char)
+	case char == '.':
+		if !strings.HasPrefix(lexer.source[lexer.position:], "...") {
+			return result, syntaxError(location, "unexpected character \".\"")
+		}
+		lexer.position += 3
+		result.Kind = tokenPunctuator
+		result.Value = "..."
+	case char == '_' || isLetter(char):
+		start := lexer.position
+		for lexer.position < len(lexer.source) && isNameChar(lexer.source[lexer.position]) {
+			lexer.position++
+		}
+		result.Kind = tokenName
+		result.Value = lexer.source[start:lexer.position]
+	case char == '-' || isDigit(char):
+		return lexer.readNumber(result)
+	case char == '"':
+		return lexer.readString(result)
+	default:
+		character, _ := utf8.DecodeRuneInString(lexer.source[lexer.position:])
+		return result, syntaxError(location, "unexpected character "+strconv.QuoteRune(character))
+	}
+	return result, nil
+}
+
+func (lexer *lexer) skipIgnored() {
+	for lexer.position < len(lexer.source) {
+		switch char := lexer.source[lexer.position]; char {
+		case ' ', '\t', ',', '\r':
+			lexer.position++
+		case '\n':
+			lexer.position++
+			lexer.line++
+			lexer.lineStart = lexer.position
+		case '#':
+			for lexer.position < len(lexer.source) && lexer.source[lexer.position] != '\n' {
+				lexer.position++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (lexer *lexer) readNumber(result token) (token, error) {
+	start := lexer.position
+	if lexer.source[lexer.position] == '-' {
+		lexer.position++
+	}
+	if !lexer.readDigits() {
+		return result, syntaxError(lexer.location(), "invalid number, expected digit")
+	}
+
+	result.Kind = tokenInt
+	if lexer.position < len(lexer.source) && lexer.source[lexer.position] == '.' {
+		lexer.position++
+		if !lexer.readDigits() {
+			return result, syntaxError(lexer.location(), "invalid number, expected digit after \".\"")
+		}
+		result.Kind = tokenFloat
+	}
+	if lexer.position < len(lexer.source) && (lexer.source[lexer.position] == 'e' || lexer.source[lexer.position] == 'E') {
+		lexer.position++
+		if lexer.position < len(lexer.source) && (lexer.source[lexer.position] == '+' || lexer.source[lexer.position] == '-') {
+			lexer.position++
+		}
+		if !lexer.readDigits() {
+			return result, syntaxError(lexer.location(), "invalid number, expected digit in exponent")
+		}
+		result.Kind = tokenFloat
+	}
+	result.Value = lexer.source[start:lexer.position]
+	return result, nil
+}
+
+func (lexer *lexer) readDigits() bool {
+	start := lexer.position
+	for lexer.position < len(lexer.source) && isDigit(lexer.source[lexer.position]) {
+		lexer.position++
+	}
+	return lexer.position > start
+}
+
+func (lexer *lexer) readString(result token) (token, error) {
+	result.Kind = tokenString
+	if strings.HasPrefix(lexer.source[lexer.position:], `"""`) {
+		lexer.position += 3
+		end := strings.Index(lexer.source[lexer.position:], `"""`)
+		if end < 0 {
+			return result, syntaxError(lexer.location(), "unterminated block string")
+		}
+		raw := lexer.source[lexer.position : lexer.position+end]
+		lexer.position += end + 3
+		if lines := strings.Count(raw, "\n"); lines > 0 {
+			lexer.line += lines
+			lexer.lineStart = strings.LastIndexByte(lexer.source[:lexer.position], '\n') + 1
+		}
+		result.Value = blockStringValue(raw)
+		return result, nil
+	}
+
+	lexer.position++
+	var builder strings.Builder
+	for lexer.position < len(lexer.source) {
+		char := lexer.source[lexer.position]
+		switch {
+		case char == '"':
+			lexer.position++
+			result.Value = builder.String()
+			return result, nil
+		case char == '\n':
+			return result, syntaxError(lexer.location(), "unterminated string")
+		case char == '\\':
+			if lexer.position+1 >= len(lexer.source) {
+				return result, syntaxError(lexer.location(), "unterminated string")
+			}
+			escape := lexer.source[lexer.position+1]
+			lexer.position += 2
+			switch escape {
+			case '"', '\\', '/':
+				builder.WriteByte(escape)
+			case 'b':
+				builder.WriteByte('\b')
+			case 'f':
+				builder.WriteByte('\f')
+			case 'n':
+				builder.WriteByte('\n')
+			case 'r':
+				builder.WriteByte('\r')
+			case 't':
+				builder.WriteByte('\t')
+			case 'u':
+				if lexer.position+4 > len(lexer.source) {
+					return result, syntaxError(lexer.location(), "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(lexer.source[lexer.position:lexer.position+4], 16, 32)
+				if err != nil {
+					return result, syntaxError(lexer.location(), "invalid unicode escape")
+				}
+				builder.WriteRune(rune(code))
+				lexer.position += 4
+			default:
+				return result, syntaxError(lexer.location(), "invalid escape sequence \\"+string(escape))
+			}
+		default:
+			builder.WriteByte(char)
+			lexer.position++
+		}
+	}
+	return result, syntaxError(lexer.location(), "unterminated string")
+}
+
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, `\"""`, `"""`), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if width := len(line) - len(trimmed); indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func isNameChar(char byte) bool {
+	return char == '_' || isLetter(char) || isDigit(char)
+}
@@ -0,0 +1,426 @@
+package graphql
+
+import "fmt"
+
+type parser struct {
+	lexer   *lexer
+	current token
+}
+
+func Parse(source string) (*Document, error) {
+	parser := &parser{lexer: newLexer(source)}
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	return parser.parseDocument()
+}
+
+func (parser *parser) advance() error {
+	next, err := parser.lexer.next()
+	if err != nil {
+		return err
+	}
+	parser.current = next
+	return nil
+}
+
+func (parser *parser) location() Location {
+	return Location{Line: parser.current.Line, Column: parser.current.Column}
+}
+
+func (parser *parser) peek(value string) bool {
+	return parser.current.Kind == tokenPunctuator && parser.current.Value == value
+}
+
+func (parser *parser) peekName(value string) bool {
+	return parser.current.Kind == tokenName && parser.current.Value == value
+}
+
+func (parser *parser) unexpected() error {
+	if parser.current.Kind == tokenEOF {
+		return syntaxError(parser.location(), "unexpected <EOF>")
+	}
+	return syntaxError(parser.location(), fmt.Sprintf("unexpected %q", parser.current.Value))
+}
+
+func (parser *parser) expect(value string) error {
+	if !parser.peek(value) {
+		if parser.current.Kind == tokenEOF {
+			return syntaxError(parser.location(), fmt.Sprintf("expected %q, found <EOF>", value))
+		}
+		return syntaxError(parser.location(), fmt.Sprintf("expected %q, found %q", value, parser.current.Value))
+	}
+	return parser.advance()
+}
+
+func (parser *parser) skip(value string) (bool, error) {
+	if !parser.peek(value) {
+		return false, nil
+	}
+	return true, parser.advance()
+}
+
+func (parser *parser) parseName() (string, error) {
+	if parser.current.Kind != tokenName {
+		return "", parser.unexpected()
+	}
+	name := parser.current.Value
+	return name, parser.advance()
+}
+
+func (parser *parser) parseDocument() (*Document, error) {
+	document := &Document{Fragments: map[string]*Fragment{}}
+	for parser.current.Kind != tokenEOF {
+		switch {
+		case parser.peek("{") || parser.peekName("query") || parser.peekName("mutation") || parser.peekName("subscription"):
+			operation, err := parser.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			document.Operations = append(document.Operations, operation)
+		case parser.peekName("fragment"):
+			fragment, err := parser.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := document.Fragments[fragment.Name]; ok {
+				return nil, validationError(fragment.Location, "There can be only one fragment named %q.", fragment.Name)
+			}
+			document.Fragments[fragment.Name] = fragment
+		default:
+			return nil, parser.unexpected()
+		}
+	}
+	if len(document.Operations) == 0 {
+		return nil, syntaxError(parser.location(), "document does not contain an operation")
+	}
+	return document, nil
+}
+
+func (parser *parser) parseOperation() (*Operation, error) {
+	operation := &Operation{Type: "query", Location: parser.location()}
+	if parser.peek("{") {
+		selectionSet, err := parser.parseSelectionSet()
+		operation.SelectionSet = selectionSet
+		return operation, err
+	}
+
+	operation.Type = parser.current.Value
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	if parser.current.Kind == tokenName {
+		operation.Name = parser.current.Value
+		if err := parser.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := parser.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !parser.peek(")") {
+			variable, err := parser.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			operation.Variables = append(operation.Variables, variable)
+		}
+		if err := parser.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	directives, err := parser.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	operation.Directives = directives
+
+	operation.SelectionSet, err = parser.parseSelectionSet()
+	return operation, err
+}
+
+func (parser *parser) parseVariableDefinition() (*VariableDefinition, error) {
+	definition := &VariableDefinition{Location: parser.location()}
+	if err := parser.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := parser.parseName()
+	if err != nil {
+		return nil, err
+	}
+	definition.Name = name
+	if err := parser.expect(":"); err != nil {
+		return nil, err
+	}
+	definition.Type, err = parser.parseTypeReference()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := parser.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		definition.Default, err = parser.parseValue(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return definition, nil
+}
+
+func (parser *parser) parseTypeReference() (*TypeReference, error) {
+	reference := &TypeReference{}
+	if ok, err := parser.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		reference.Elem, err = parser.parseTypeReference()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		reference.Name, err = parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nonNull, err := parser.skip("!")
+	reference.NonNull = nonNull
+	return reference, err
+}
+
+func (parser *parser) parseSelectionSet() ([]Selection, error) {
+	if err := parser.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []Selection
+	for !parser.peek("}") {
+		selection, err := parser.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, parser.unexpected()
+	}
+	return selections, parser.advance()
+}
+
+func (parser *parser) parseSelection() (Selection, error) {
+	location := parser.location()
+	if ok, err := parser.skip("..."); err != nil {
+		return nil, err
+	} else if !ok {
+		return parser.parseField()
+	}
+
+	if parser.current.Kind == tokenName && parser.current.Value != "on" {
+		name, err := parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+		directives, err := parser.parseDirectives()
+		return &FragmentSpread{Name: name, Directives: directives, Location: location}, err
+	}
+
+	fragment := &InlineFragment{Location: location}
+	if parser.peekName("on") {
+		if err := parser.advance(); err != nil {
+			return nil, err
+		}
+		typeCondition, err := parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+		fragment.TypeCondition = typeCondition
+	}
+	directives, err := parser.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	fragment.Directives = directives
+	fragment.SelectionSet, err = parser.parseSelectionSet()
+	return fragment, err
+}
+
+func (parser *parser) parseField() (*Field, error) {
+	field := &Field{Location: parser.location()}
+	name, err := parser.parseName()
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name
+	if ok, err := parser.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		field.Name, err = parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	field.Arguments, err = parser.parseArguments(false)
+	if err != nil {
+		return nil, err
+	}
+	field.Directives, err = parser.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	if parser.peek("{") {
+		field.SelectionSet, err = parser.parseSelectionSet()
+	}
+	return field, err
+}
+
+func (parser *parser) parseArguments(constant bool) ([]*Argument, error) {
+	if ok, err := parser.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	var arguments []*Argument
+	for !parser.peek(")") {
+		argument := &Argument{Location: parser.location()}
+		name, err := parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+		argument.Name = name
+		if err := parser.expect(":"); err != nil {
+			return nil, err
+		}
+		argument.Value, err = parser.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	if len(arguments) == 0 {
+		return nil, parser.unexpected()
+	}
+	return arguments, parser.advance()
+}
+
+func (parser *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+	for parser.peek("@") {
+		directive := &Directive{Location: parser.location()}
+		if err := parser.advance(); err != nil {
+			return nil, err
+		}
+		name, err := parser.parseName()
+		if err != nil {
+			return nil, err
+		}
+		directive.Name = name
+		directive.Arguments, err = parser.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive)
+	}
+	return directives, nil
+}
+
+func (parser *parser) parseFragment() (*Fragment, error) {
+	fragment := &Fragment{Location: parser.location()}
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	if parser.peekName("on") {
+		return nil, parser.unexpected()
+	}
+	name, err := parser.parseName()
+	if err != nil {
+		return nil, err
+	}
+	fragment.Name = name
+	if !parser.peekName("on") {
+		return nil, syntaxError(parser.location(), "expected \"on\"")
+	}
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	fragment.TypeCondition, err = parser.parseName()
+	if err != nil {
+		return nil, err
+	}
+	fragment.Directives, err = parser.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	fragment.SelectionSet, err = parser.parseSelectionSet()
+	return fragment, err
+}
+
+func (parser *parser) parseValue(constant bool) (Value, error) {
+	current := parser.current
+	switch current.Kind {
+	case tokenPunctuator:
+		switch current.Value {
+		case "$":
+			if constant {
+				return nil, parser.unexpected()
+			}
+			if err := parser.advance(); err != nil {
+				return nil, err
+			}
+			name, err := parser.parseName()
+			return Variable{Name: name}, err
+		case "[":
+			if err := parser.advance(); err != nil {
+				return nil, err
+			}
+			list := ListValue{}
+			for !parser.peek("]") {
+				item, err := parser.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, parser.advance()
+		case "{":
+			if err := parser.advance(); err != nil {
+				return nil, err
+			}
+			object := ObjectValue{}
+			for !parser.peek("}") {
+				name, err := parser.parseName()
+				if err != nil {
+					return nil, err
+				}
+				if err := parser.expect(":"); err != nil {
+					return nil, err
+				}
+				value, err := parser.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, &ObjectField{Name: name, Value: value})
+			}
+			return object, parser.advance()
+		}
+	case tokenInt:
+		return IntValue(current.Value), parser.advance()
+	case tokenFloat:
+		return FloatValue(current.Value), parser.advance()
+	case tokenString:
+		return StringValue(current.Value), parser.advance()
+	case tokenName:
+		switch current.Value {
+		case "true", "false":
+			return BooleanValue(current.Value == "true"), parser.advance()
+		case "null":
+			return NullValue{}, parser.advance()
+		default:
+			return EnumValue(current.Value), parser.advance()
+		}
+	}
+	return nil, parser.unexpected()
+}
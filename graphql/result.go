@@ -0,0 +1,45 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type ResultMap struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+func newResultMap() *ResultMap {
+	return &ResultMap{Values: map[string]interface{}{}}
+}
+
+func (result *ResultMap) Set(key string, value interface{}) {
+	if _, ok := result.Values[key]; !ok {
+		result.Keys = append(result.Keys, key)
+	}
+	result.Values[key] = value
+}
+
+func (result *ResultMap) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range result.Keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(result.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,271 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Type interface {
+	String() string
+}
+
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+type ResolveFunc func(params ResolveParams) (interface{}, error)
+
+type AuthorizeFunc func(ctx context.Context) error
+
+type ComplexityFunc func(args map[string]interface{}, childComplexity int) int
+
+type RecoverFunc func(ctx context.Context, recovered interface{}) error
+
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+	Recover  RecoverFunc
+}
+
+type Object struct {
+	Name   string
+	Fields Fields
+}
+
+type Fields map[string]*FieldDefinition
+
+type FieldDefinition struct {
+	Type       Type
+	Args       []*ArgumentDefinition
+	Resolve    ResolveFunc
+	Authorize  AuthorizeFunc
+	Complexity ComplexityFunc
+}
+
+type ArgumentDefinition struct {
+	Name    string
+	Type    Type
+	Default interface{}
+}
+
+type InputObject struct {
+	Name   string
+	Fields []*ArgumentDefinition
+}
+
+type List struct {
+	Of Type
+}
+
+type NonNull struct {
+	Of Type
+}
+
+type Scalar struct {
+	Name       string
+	Serialize  func(value interface{}) (interface{}, error)
+	ParseValue func(value interface{}) (interface{}, error)
+}
+
+func (object *Object) String() string {
+	return object.Name
+}
+
+func (object *InputObject) String() string {
+	return object.Name
+}
+
+func (list *List) String() string {
+	return "[" + list.Of.String() + "]"
+}
+
+func (nonNull *NonNull) String() string {
+	return nonNull.Of.String() + "!"
+}
+
+func (scalar *Scalar) String() string {
+	return scalar.Name
+}
+
+func (definition *FieldDefinition) Argument(name string) *ArgumentDefinition {
+	for _, argument := range definition.Args {
+		if argument.Name == name {
+			return argument
+		}
+	}
+	return nil
+}
+
+func namedType(fieldType Type) Type {
+	for {
+		switch wrapped := fieldType.(type) {
+		case *NonNull:
+			fieldType = wrapped.Of
+		case *List:
+			fieldType = wrapped.Of
+		default:
+			return fieldType
+		}
+	}
+}
+
+var Int = &Scalar{
+	Name: "Int",
+	Serialize: func(value interface{}) (interface{}, error) {
+		return coerceInt(value)
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		return coerceInt(value)
+	},
+}
+
+var Float = &Scalar{
+	Name: "Float",
+	Serialize: func(value interface{}) (interface{}, error) {
+		return coerceFloat(value)
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		return coerceFloat(value)
+	},
+}
+
+var String = &Scalar{
+	Name: "String",
+	Serialize: func(value interface{}) (interface{}, error) {
+		switch value := value.(type) {
+		case string:
+			return value, nil
+		case fmt.Stringer:
+			return value.String(), nil
+		default:
+			return fmt.Sprint(value), nil
+		}
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("String cannot represent a non string value: %v", value)
+		}
+		return text, nil
+	},
+}
+
+var Boolean = &Scalar{
+	Name: "Boolean",
+	Serialize: func(value interface{}) (interface{}, error) {
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %v", value)
+		}
+		return boolean, nil
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %v", value)
+		}
+		return boolean, nil
+	},
+}
+
+var ID = &Scalar{
+	Name: "ID",
+	Serialize: func(value interface{}) (interface{}, error) {
+		return coerceId(value)
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		return coerceId(value)
+	},
+}
+
+func coerceInt(value interface{}) (interface{}, error) {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number := reflected.Int()
+		if number > math.MaxInt32 || number < math.MinInt32 {
+			return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %d", number)
+		}
+		return int(number), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflected.Uint() > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %d", reflected.Uint())
+		}
+		return int(reflected.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		number := reflected.Float()
+		if number != math.Trunc(number) || number > math.MaxInt32 || number < math.MinInt32 {
+			return nil, fmt.Errorf("Int cannot represent non-integer value: %v", number)
+		}
+		return int(number), nil
+	default:
+		return nil, fmt.Errorf("Int cannot represent non-integer value: %v", value)
+	}
+}
+
+func coerceFloat(value interface{}) (interface{}, error) {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflected.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflected.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), nil
+	default:
+		return nil, fmt.Errorf("Float cannot represent non numeric value: %v", value)
+	}
+}
+
+func coerceId(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case int:
+		return strconv.Itoa(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		if value == math.Trunc(value) {
+			return strconv.FormatInt(int64(value), 10), nil
+		}
+	}
+	return nil, fmt.Errorf("ID cannot represent value: %v", value)
+}
+
+func defaultResolve(params ResolveParams, name string) (interface{}, error) {
+	if params.Source == nil {
+		return nil, nil
+	}
+	if source, ok := params.Source.(map[string]interface{}); ok {
+		return source[name], nil
+	}
+
+	value := reflect.ValueOf(params.Source)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && strings.EqualFold(field.Name, name)) {
+			return value.Field(i).Interface(), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,241 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func (schema *Schema) inputTypes() map[string]Type {
+	types := map[string]Type{}
+	for _, scalar := range []*Scalar{Int, Float, String, Boolean, ID} {
+		types[scalar.Name] = scalar
+	}
+
+	var collect func(inputType Type)
+	collect = func(inputType Type) {
+		switch named := namedType(inputType).(type) {
+		case *Scalar:
+			types[named.Name] = named
+		case *InputObject:
+			if _, ok := types[named.Name]; ok {
+				return
+			}
+			types[named.Name] = named
+			for _, field := range named.Fields {
+				collect(field.Type)
+			}
+		}
+	}
+	for _, root := range []*Object{schema.Query, schema.Mutation} {
+		if root == nil {
+			continue
+		}
+		for _, field := range root.Fields {
+			for _, argument := range field.Args {
+				collect(argument.Type)
+			}
+		}
+	}
+	return types
+}
+
+func (schema *Schema) resolveTypeReference(reference *TypeReference, types map[string]Type) (Type, bool) {
+	var resolved Type
+	if reference.Elem != nil {
+		elem, ok := schema.resolveTypeReference(reference.Elem, types)
+		if !ok {
+			return nil, false
+		}
+		resolved = &List{Of: elem}
+	} else {
+		named, ok := types[reference.Name]
+		if !ok {
+			return nil, false
+		}
+		resolved = named
+	}
+	if reference.NonNull {
+		resolved = &NonNull{Of: resolved}
+	}
+	return resolved, true
+}
+
+func coerceVariableValue(value interface{}, inputType Type) (interface{}, error) {
+	if nonNull, ok := inputType.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected non-nullable type %s not to be null", inputType)
+		}
+		return coerceVariableValue(value, nonNull.Of)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch inputType := inputType.(type) {
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			itemValue, err := coerceVariableValue(item, inputType.Of)
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+			coerced[i] = itemValue
+		}
+		return coerced, nil
+	case *InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected type %s to be an object", inputType)
+		}
+		for name := range object {
+			if inputField(inputType, name) == nil {
+				return nil, fmt.Errorf("field %q is not defined by type %s", name, inputType)
+			}
+		}
+		coerced := map[string]interface{}{}
+		for _, field := range inputType.Fields {
+			fieldValue, ok := object[field.Name]
+			if !ok {
+				if err := applyDefault(coerced, field); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			coercedValue, err := coerceVariableValue(fieldValue, field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			coerced[field.Name] = coercedValue
+		}
+		return coerced, nil
+	case *Scalar:
+		return inputType.ParseValue(value)
+	default:
+		return nil, fmt.Errorf("type %s is not an input type", inputType)
+	}
+}
+
+func coerceLiteral(value Value, inputType Type, variables map[string]interface{}) (interface{}, error) {
+	if variable, ok := value.(Variable); ok {
+		return variables[variable.Name], nil
+	}
+
+	if nonNull, ok := inputType.(*NonNull); ok {
+		if _, isNull := value.(NullValue); isNull {
+			return nil, fmt.Errorf("expected value of type %s, found null", inputType)
+		}
+		coerced, err := coerceLiteral(value, nonNull.Of, variables)
+		if err == nil && coerced == nil {
+			err = fmt.Errorf("expected value of type %s, found null", inputType)
+		}
+		return coerced, err
+	}
+	if _, isNull := value.(NullValue); isNull {
+		return nil, nil
+	}
+
+	switch inputType := inputType.(type) {
+	case *List:
+		items, ok := value.(ListValue)
+		if !ok {
+			items = ListValue{value}
+		}
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			itemValue, err := coerceLiteral(item, inputType.Of, variables)
+			if err != nil {
+				return nil, err
+			}
+			coerced[i] = itemValue
+		}
+		return coerced, nil
+	case *InputObject:
+		object, ok := value.(ObjectValue)
+		if !ok {
+			return nil, fmt.Errorf("expected value of type %s to be an object", inputType)
+		}
+		provided := map[string]Value{}
+		for _, field := range object {
+			if inputField(inputType, field.Name) == nil {
+				return nil, fmt.Errorf("field %q is not defined by type %s", field.Name, inputType)
+			}
+			provided[field.Name] = field.Value
+		}
+		coerced := map[string]interface{}{}
+		for _, field := range inputType.Fields {
+			fieldValue, ok := provided[field.Name]
+			if variable, isVariable := fieldValue.(Variable); isVariable {
+				_, ok = variables[variable.Name]
+			}
+			if !ok {
+				if err := applyDefault(coerced, field); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			coercedValue, err := coerceLiteral(fieldValue, field.Type, variables)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			coerced[field.Name] = coercedValue
+		}
+		return coerced, nil
+	case *Scalar:
+		literal, err := literalValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return inputType.ParseValue(literal)
+	default:
+		return nil, fmt.Errorf("type %s is not an input type", inputType)
+	}
+}
+
+func applyDefault(coerced map[string]interface{}, field *ArgumentDefinition) error {
+	if field.Default != nil {
+		coerced[field.Name] = field.Default
+		return nil
+	}
+	if _, ok := field.Type.(*NonNull); ok {
+		return fmt.Errorf("field %q of required type %s was not provided", field.Name, field.Type)
+	}
+	return nil
+}
+
+func inputField(inputObject *InputObject, name string) *ArgumentDefinition {
+	for _, field := range inputObject.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func literalValue(value Value) (interface{}, error) {
+	switch value := value.(type) {
+	case IntValue:
+		number, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s", value)
+		}
+		return number, nil
+	case FloatValue:
+		number, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %s", value)
+		}
+		return number, nil
+	case StringValue:
+		return string(value), nil
+	case BooleanValue:
+		return bool(value), nil
+	case EnumValue:
+		return nil, fmt.Errorf("enum value %s is not supported here", value)
+	default:
+		return nil, fmt.Errorf("unexpected value %v", value)
+	}
+}
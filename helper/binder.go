@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+)
+
+type BindError struct {
+	Source  string
+	Field   string
+	Message string
+}
+
+func (err BindError) Error() string {
+	return fmt.Sprintf("%s parameter %s %s", err.Source, err.Field, err.Message)
+}
+
+func Bind(request *http.Request, params httprouter.Params, result interface{}, validate *validator.Validate) {
+	if hasRequestBody(request) {
+		ReadFromRequestBody(request, result)
+	}
+
+	value := reflect.ValueOf(result).Elem()
+	bindFields(request, params, value)
+
+	if validate != nil {
+		err := validate.Struct(result)
+		PanicIfError(err)
+	}
+}
+
+func hasRequestBody(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return request.Body != nil && request.Body != http.NoBody && request.ContentLength != 0
+	default:
+		return false
+	}
+}
+
+func bindFields(request *http.Request, params httprouter.Params, value reflect.Value) {
+	valueType := value.Type()
+	query := request.URL.Query()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			bindFields(request, params, value.Field(i))
+			continue
+		}
+
+		if name, ok := field.Tag.Lookup("path"); ok {
+			if raw := params.ByName(name); raw != "" {
+				setField(value.Field(i), "path", name, []string{raw})
+			}
+		}
+		if name, ok := field.Tag.Lookup("query"); ok {
+			if raw, exists := query[name]; exists {
+				setField(value.Field(i), "query", name, raw)
+			}
+		}
+		if name, ok := field.Tag.Lookup("header"); ok {
+			if raw := request.Header.Values(name); len(raw) > 0 {
+				setField(value.Field(i), "header", name, raw)
+			}
+		}
+	}
+}
+
+func setField(field reflect.Value, source string, name string, raw []string) {
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+		for i, item := range raw {
+			setScalar(slice.Index(i), source, name, item)
+		}
+		field.Set(slice)
+		return
+	}
+	setScalar(field, source, name, raw[0])
+}
+
+func setScalar(field reflect.Value, source string, name string, raw string) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			panic(BindError{Source: source, Field: name, Message: "must be an integer"})
+		}
+		field.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			panic(BindError{Source: source, Field: name, Message: "must be a positive integer"})
+		}
+		field.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			panic(BindError{Source: source, Field: name, Message: "must be a number"})
+		}
+		field.SetFloat(number)
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(raw)
+		if err != nil {
+			panic(BindError{Source: source, Field: name, Message: "must be a boolean"})
+		}
+		field.SetBool(boolean)
+	default:
+		panic(fmt.Errorf("field %s of kind %s can not be bound", name, field.Kind()))
+	}
+}
@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+type TrustedProxies []*net.IPNet
+
+func ParseTrustedProxies(cidrs ...string) TrustedProxies {
+	var proxies TrustedProxies
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		PanicIfError(err)
+		proxies = append(proxies, network)
+	}
+	return proxies
+}
+
+func (proxies TrustedProxies) Contains(ip net.IP) bool {
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func ClientIP(request *http.Request, proxies TrustedProxies) string {
+	remoteAddr, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		remoteAddr = request.RemoteAddr
+	}
+
+	remoteIP := net.ParseIP(remoteAddr)
+	if remoteIP == nil || !proxies.Contains(remoteIP) {
+		return remoteAddr
+	}
+
+	forwardedFor := strings.Split(request.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwardedFor[i]))
+		if ip == nil {
+			break
+		}
+		if !proxies.Contains(ip) {
+			return ip.String()
+		}
+	}
+
+	if realIP := net.ParseIP(strings.TrimSpace(request.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+	return remoteAddr
+}
@@ -0,0 +1,132 @@
+package helper
+
+import (
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Codec interface {
+	Format() string
+	MediaTypes() []string
+	Encode(writer io.Writer, value interface{}) error
+	Decode(reader io.Reader, value interface{}) error
+}
+
+type CodecRegistry struct {
+	codecs []Codec
+}
+
+var DefaultCodecs = NewCodecRegistry(JSONCodec{}, XMLCodec{}, YAMLCodec{}, MessagePackCodec{}, CSVCodec{}, JsonApiCodec{})
+
+func NewCodecRegistry(codecs ...Codec) *CodecRegistry {
+	registry := &CodecRegistry{}
+	for _, codec := range codecs {
+		registry.Register(codec)
+	}
+	return registry
+}
+
+func (registry *CodecRegistry) Register(codec Codec) {
+	for i, registered := range registry.codecs {
+		if registered.Format() == codec.Format() {
+			registry.codecs[i] = codec
+			return
+		}
+	}
+	registry.codecs = append(registry.codecs, codec)
+}
+
+func (registry *CodecRegistry) Default() Codec {
+	return registry.codecs[0]
+}
+
+func (registry *CodecRegistry) MediaTypes() []string {
+	var mediaTypes []string
+	for _, codec := range registry.codecs {
+		mediaTypes = append(mediaTypes, codec.MediaTypes()[0])
+	}
+	return mediaTypes
+}
+
+func (registry *CodecRegistry) ForFormat(format string) (Codec, bool) {
+	for _, codec := range registry.codecs {
+		if strings.EqualFold(codec.Format(), format) {
+			return codec, true
+		}
+	}
+	return nil, false
+}
+
+func (registry *CodecRegistry) ForContentType(contentType string) (Codec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	for _, codec := range registry.codecs {
+		for _, supported := range codec.MediaTypes() {
+			if mediaType == supported {
+				return codec, true
+			}
+		}
+	}
+	if strings.HasSuffix(mediaType, "+json") {
+		return registry.ForFormat("json")
+	}
+	return nil, false
+}
+
+type acceptRange struct {
+	mediaType string
+	quality   float64
+	order     int
+}
+
+func (registry *CodecRegistry) Negotiate(accept string) (Codec, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return registry.codecs[0], true
+	}
+
+	var ranges []acceptRange
+	for i, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality, order: i})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	for _, acceptRange := range ranges {
+		switch {
+		case acceptRange.mediaType == "*/*":
+			return registry.codecs[0], true
+		case strings.HasSuffix(acceptRange.mediaType, "/*"):
+			prefix := strings.TrimSuffix(acceptRange.mediaType, "*")
+			for _, codec := range registry.codecs {
+				for _, mediaType := range codec.MediaTypes() {
+					if strings.HasPrefix(mediaType, prefix) {
+						return codec, true
+					}
+				}
+			}
+		default:
+			if codec, ok := registry.ForContentType(acceptRange.mediaType); ok {
+				return codec, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,130 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+type CSVCodec struct{}
+
+func (codec CSVCodec) Format() string {
+	return "csv"
+}
+
+func (codec CSVCodec) MediaTypes() []string {
+	return []string{"text/csv"}
+}
+
+func (codec CSVCodec) Encode(writer io.Writer, value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+
+	var rows []interface{}
+	if envelope, ok := tree.(*orderedMap); ok {
+		switch data := envelope.Values["data"].(type) {
+		case []interface{}:
+			rows = data
+		case *orderedMap:
+			rows = []interface{}{data}
+		default:
+			rows = []interface{}{envelope}
+		}
+	} else if list, ok := tree.([]interface{}); ok {
+		rows = list
+	} else {
+		rows = []interface{}{tree}
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	records := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		records[i] = map[string]string{}
+		flattenCSV("", row, records[i], func(column string) {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		})
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write(columns)
+	for _, record := range records {
+		line := make([]string, len(columns))
+		for i, column := range columns {
+			line[i] = record[column]
+		}
+		csvWriter.Write(line)
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func flattenCSV(prefix string, tree interface{}, record map[string]string, addColumn func(string)) {
+	switch value := tree.(type) {
+	case *orderedMap:
+		for _, key := range value.Keys {
+			column := key
+			if prefix != "" {
+				column = prefix + "." + key
+			}
+			flattenCSV(column, value.Values[key], record, addColumn)
+		}
+	case []interface{}:
+		encoded, _ := json.Marshal(plainTree(value))
+		addColumn(prefix)
+		record[prefix] = string(encoded)
+	default:
+		column := prefix
+		if column == "" {
+			column = "value"
+		}
+		addColumn(column)
+		if text, ok := value.(string); ok {
+			record[column] = csvCell(text)
+		} else {
+			record[column] = scalarString(value)
+		}
+	}
+}
+
+func csvCell(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func (codec CSVCodec) Decode(reader io.Reader, value interface{}) error {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) < 2 {
+		return errors.New("csv document must contain a header row and at least one record")
+	}
+
+	var rows []interface{}
+	for _, record := range records[1:] {
+		row := newOrderedMap()
+		for i, column := range records[0] {
+			if i < len(record) {
+				row.Set(column, record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	target := reflect.ValueOf(value)
+	if target.Kind() == reflect.Ptr && target.Elem().Kind() == reflect.Slice {
+		return assignTree(rows, target)
+	}
+	return assignTree(rows[0], target)
+}
@@ -0,0 +1,24 @@
+package helper
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type JSONCodec struct{}
+
+func (codec JSONCodec) Format() string {
+	return "json"
+}
+
+func (codec JSONCodec) MediaTypes() []string {
+	return []string{"application/json"}
+}
+
+func (codec JSONCodec) Encode(writer io.Writer, value interface{}) error {
+	return json.NewEncoder(writer).Encode(value)
+}
+
+func (codec JSONCodec) Decode(reader io.Reader, value interface{}) error {
+	return json.NewDecoder(reader).Decode(value)
+}
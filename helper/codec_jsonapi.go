@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"project-restful-api/model/web"
+	"reflect"
+)
+
+type JsonApiCodec struct{}
+
+func (codec JsonApiCodec) Format() string {
+	return "jsonapi"
+}
+
+func (codec JsonApiCodec) MediaTypes() []string {
+	return []string{"application/vnd.api+json"}
+}
+
+func (codec JsonApiCodec) Encode(writer io.Writer, value interface{}) error {
+	if response, ok := value.(web.WebResponse); ok {
+		document, err := ToJsonApiDocument(response)
+		if err != nil {
+			return err
+		}
+		value = document
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
+
+func (codec JsonApiCodec) Decode(reader io.Reader, value interface{}) error {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	tree, err := readTree(decoder)
+	if err != nil {
+		return err
+	}
+
+	document, ok := tree.(*orderedMap)
+	if !ok {
+		return errors.New("document must be a JSON object")
+	}
+	resource, ok := document.Values["data"].(*orderedMap)
+	if !ok {
+		return errors.New("document must contain a data resource object")
+	}
+
+	fields := newOrderedMap()
+	if id, ok := resource.Values["id"]; ok {
+		fields.Set("id", id)
+	}
+	if attributes, ok := resource.Values["attributes"].(*orderedMap); ok {
+		for _, key := range attributes.Keys {
+			fields.Set(key, attributes.Values[key])
+		}
+	}
+	return assignTree(fields, reflect.ValueOf(value))
+}
@@ -0,0 +1,205 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+type MessagePackCodec struct{}
+
+func (codec MessagePackCodec) Format() string {
+	return "msgpack"
+}
+
+func (codec MessagePackCodec) MediaTypes() []string {
+	return []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}
+}
+
+func (codec MessagePackCodec) Encode(writer io.Writer, value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(writer)
+	if err := writeMessagePack(msgpack.NewEncoder(buffered), tree); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func writeMessagePack(encoder *msgpack.Encoder, tree interface{}) error {
+	switch value := tree.(type) {
+	case nil:
+		return encoder.EncodeNil()
+	case bool:
+		return encoder.EncodeBool(value)
+	case json.Number:
+		if integer, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return encoder.EncodeInt(integer)
+		}
+		if unsigned, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			return encoder.EncodeUint(unsigned)
+		}
+		float, err := value.Float64()
+		if err != nil {
+			return err
+		}
+		return encoder.EncodeFloat64(float)
+	case string:
+		return encoder.EncodeString(value)
+	case []interface{}:
+		if err := encoder.EncodeArrayLen(len(value)); err != nil {
+			return err
+		}
+		for _, item := range value {
+			if err := writeMessagePack(encoder, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *orderedMap:
+		if err := encoder.EncodeMapLen(len(value.Keys)); err != nil {
+			return err
+		}
+		for _, key := range value.Keys {
+			if err := encoder.EncodeString(key); err != nil {
+				return err
+			}
+			if err := writeMessagePack(encoder, value.Values[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("msgpack can not encode %T", tree)
+}
+
+func (codec MessagePackCodec) Decode(reader io.Reader, value interface{}) error {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if len(content) == 0 {
+		return io.EOF
+	}
+
+	document := &messagePackDocument{reader: bytes.NewReader(content)}
+	document.decoder = msgpack.NewDecoder(document.reader)
+	tree, err := document.read(0)
+	if err != nil {
+		return err
+	}
+	return assignTree(tree, reflect.ValueOf(value))
+}
+
+const maxMessagePackDepth = 64
+
+type messagePackDocument struct {
+	reader  *bytes.Reader
+	decoder *msgpack.Decoder
+}
+
+func (document *messagePackDocument) read(depth int) (interface{}, error) {
+	if depth > maxMessagePackDepth {
+		return nil, fmt.Errorf("msgpack document is nested too deeply")
+	}
+	code, err := document.decoder.PeekCode()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case msgpcode.IsFixedMap(code) || code == msgpcode.Map16 || code == msgpcode.Map32:
+		return document.readMap(depth)
+	case msgpcode.IsFixedArray(code) || code == msgpcode.Array16 || code == msgpcode.Array32:
+		return document.readArray(depth)
+	case msgpcode.IsString(code) || msgpcode.IsBin(code):
+		return document.readString()
+	}
+
+	value, err := document.decoder.DecodeInterfaceLoose()
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case nil, bool:
+		return value, nil
+	case int64:
+		return json.Number(strconv.FormatInt(value, 10)), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(value, 10)), nil
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64)), nil
+	}
+	return nil, fmt.Errorf("msgpack type 0x%02x is not supported", code)
+}
+
+func (document *messagePackDocument) readString() (interface{}, error) {
+	size, err := document.decoder.DecodeBytesLen()
+	if err != nil {
+		return nil, err
+	}
+	if size > document.reader.Len() {
+		return nil, fmt.Errorf("msgpack string length %d exceeds the document", size)
+	}
+	buffer := make([]byte, size)
+	if err := document.decoder.ReadFull(buffer); err != nil {
+		return nil, err
+	}
+	return string(buffer), nil
+}
+
+func (document *messagePackDocument) readArray(depth int) (interface{}, error) {
+	size, err := document.decoder.DecodeArrayLen()
+	if err != nil {
+		return nil, err
+	}
+	if size > document.reader.Len() {
+		return nil, fmt.Errorf("msgpack array length %d exceeds the document", size)
+	}
+	list := make([]interface{}, 0, size)
+	for i := 0; i < size; i++ {
+		item, err := document.read(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func (document *messagePackDocument) readMap(depth int) (interface{}, error) {
+	size, err := document.decoder.DecodeMapLen()
+	if err != nil {
+		return nil, err
+	}
+	if size > document.reader.Len()/2 {
+		return nil, fmt.Errorf("msgpack map length %d exceeds the document", size)
+	}
+	object := newOrderedMap()
+	for i := 0; i < size; i++ {
+		key, err := document.read(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		switch key.(type) {
+		case *orderedMap, []interface{}:
+			return nil, fmt.Errorf("msgpack map keys must be scalars")
+		}
+		value, err := document.read(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		object.Set(scalarString(key), value)
+	}
+	return object, nil
+}
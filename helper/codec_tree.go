@@ -0,0 +1,292 @@
+package helper
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type orderedMap struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{Values: map[string]interface{}{}}
+}
+
+func (object *orderedMap) Set(key string, value interface{}) {
+	if _, ok := object.Values[key]; !ok {
+		object.Keys = append(object.Keys, key)
+	}
+	object.Values[key] = value
+}
+
+func (object *orderedMap) Project(keys []string) {
+	keep := map[string]bool{}
+	for _, key := range keys {
+		keep[key] = true
+	}
+	var projected []string
+	for _, key := range object.Keys {
+		if keep[key] {
+			projected = append(projected, key)
+		} else {
+			delete(object.Values, key)
+		}
+	}
+	object.Keys = projected
+}
+
+func (object *orderedMap) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range object.Keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(object.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func toTree(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	return readTree(decoder)
+}
+
+func readTree(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := newOrderedMap()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readTree(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key.(string), value)
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := readTree(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	default:
+		return token, nil
+	}
+}
+
+func assignTree(tree interface{}, target reflect.Value) error {
+	if tree == nil {
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return assignTree(tree, target.Elem())
+	}
+
+	if target.CanAddr() {
+		if unmarshaler, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			text, err := scalarTree(tree)
+			if err != nil {
+				return err
+			}
+			return unmarshaler.UnmarshalText([]byte(text))
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, err := scalarTree(tree); err != nil {
+			return err
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(plainTree(tree)))
+	case reflect.Struct:
+		object, ok := tree.(*orderedMap)
+		if !ok {
+			return fmt.Errorf("expected an object for %s", target.Type())
+		}
+		return assignStruct(object, target)
+	case reflect.Map:
+		object, ok := tree.(*orderedMap)
+		if !ok || target.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("expected an object for %s", target.Type())
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+		for _, key := range object.Keys {
+			element := reflect.New(target.Type().Elem()).Elem()
+			if err := assignTree(object.Values[key], element); err != nil {
+				return err
+			}
+			target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+		}
+	case reflect.Slice:
+		list, ok := tree.([]interface{})
+		if !ok {
+			list = []interface{}{tree}
+		}
+		slice := reflect.MakeSlice(target.Type(), len(list), len(list))
+		for i, item := range list {
+			if err := assignTree(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+	case reflect.String:
+		target.SetString(scalarString(tree))
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(scalarString(tree))
+		if err != nil {
+			return fmt.Errorf("expected a boolean, got %q", scalarString(tree))
+		}
+		target.SetBool(boolean)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(scalarString(tree), 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", scalarString(tree))
+		}
+		target.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(scalarString(tree), 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a positive integer, got %q", scalarString(tree))
+		}
+		target.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(scalarString(tree), target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", scalarString(tree))
+		}
+		target.SetFloat(number)
+	default:
+		return fmt.Errorf("can not decode into %s", target.Type())
+	}
+	return nil
+}
+
+func assignStruct(object *orderedMap, target reflect.Value) error {
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := assignStruct(object, target.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		for _, key := range object.Keys {
+			if strings.EqualFold(key, name) {
+				if err := assignTree(object.Values[key], target.Field(i)); err != nil {
+					return fmt.Errorf("field %q: %w", name, err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func scalarTree(tree interface{}) (string, error) {
+	switch tree.(type) {
+	case *orderedMap:
+		return "", fmt.Errorf("expected a scalar, got an object")
+	case []interface{}:
+		return "", fmt.Errorf("expected a scalar, got a list")
+	}
+	return scalarString(tree), nil
+}
+
+func scalarString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func plainTree(tree interface{}) interface{} {
+	switch tree := tree.(type) {
+	case *orderedMap:
+		object := map[string]interface{}{}
+		for _, key := range tree.Keys {
+			object[key] = plainTree(tree.Values[key])
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(tree))
+		for i, item := range tree {
+			list[i] = plainTree(item)
+		}
+		return list
+	default:
+		return tree
+	}
+}
@@ -0,0 +1,138 @@
+package helper
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type XMLCodec struct{}
+
+func (codec XMLCodec) Format() string {
+	return "xml"
+}
+
+func (codec XMLCodec) MediaTypes() []string {
+	return []string{"application/xml", "text/xml"}
+}
+
+func (codec XMLCodec) Encode(writer io.Writer, value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(writer)
+	buffered.WriteString(xml.Header)
+	writeXMLElement(buffered, "response", tree)
+	buffered.WriteString("\n")
+	return buffered.Flush()
+}
+
+func writeXMLElement(writer *bufio.Writer, name string, tree interface{}) {
+	name = xmlName(name)
+	switch tree := tree.(type) {
+	case nil:
+		writer.WriteString("<" + name + "/>")
+	case *orderedMap:
+		writer.WriteString("<" + name + ">")
+		for _, key := range tree.Keys {
+			writeXMLElement(writer, key, tree.Values[key])
+		}
+		writer.WriteString("</" + name + ">")
+	case []interface{}:
+		writer.WriteString("<" + name + ">")
+		for _, item := range tree {
+			writeXMLElement(writer, "item", item)
+		}
+		writer.WriteString("</" + name + ">")
+	default:
+		writer.WriteString("<" + name + ">")
+		xml.EscapeText(writer, []byte(scalarString(tree)))
+		writer.WriteString("</" + name + ">")
+	}
+}
+
+func xmlName(name string) string {
+	var builder strings.Builder
+	for i, character := range name {
+		valid := unicode.IsLetter(character) || character == '_' || (i > 0 && (unicode.IsDigit(character) || character == '-' || character == '.'))
+		if valid {
+			builder.WriteRune(character)
+		} else {
+			builder.WriteRune('_')
+		}
+	}
+	if builder.Len() == 0 {
+		return "_"
+	}
+	return builder.String()
+}
+
+func (codec XMLCodec) Decode(reader io.Reader, value interface{}) error {
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("xml document has no root element")
+			}
+			return err
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			tree, err := readXMLElement(decoder)
+			if err != nil {
+				return err
+			}
+			return assignTree(tree, reflect.ValueOf(value))
+		}
+	}
+}
+
+func readXMLElement(decoder *xml.Decoder) (interface{}, error) {
+	object := newOrderedMap()
+	repeated := map[string]bool{}
+	var text strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := readXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+			name := token.Name.Local
+			existing, ok := object.Values[name]
+			switch {
+			case !ok:
+				object.Set(name, child)
+			case repeated[name]:
+				object.Values[name] = append(existing.([]interface{}), child)
+			default:
+				object.Values[name] = []interface{}{existing, child}
+				repeated[name] = true
+			}
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			if len(object.Keys) == 0 {
+				return strings.TrimSpace(text.String()), nil
+			}
+			if len(object.Keys) == 1 && object.Keys[0] == "item" {
+				if repeated["item"] {
+					return object.Values["item"], nil
+				}
+				return []interface{}{object.Values["item"]}, nil
+			}
+			return object, nil
+		}
+	}
+}
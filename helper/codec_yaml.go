@@ -0,0 +1,139 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+type YAMLCodec struct{}
+
+func (codec YAMLCodec) Format() string {
+	return "yaml"
+}
+
+func (codec YAMLCodec) MediaTypes() []string {
+	return []string{"application/yaml", "application/x-yaml", "text/yaml"}
+}
+
+func (codec YAMLCodec) Encode(writer io.Writer, value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(tree)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func yamlNode(tree interface{}) *yaml.Node {
+	switch value := tree.(type) {
+	case *orderedMap:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range value.Keys {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, yamlNode(value.Values[key]))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range value {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}
+	case json.Number:
+		if _, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value.String()}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value.String()}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: scalarString(value)}
+	}
+}
+
+func (codec YAMLCodec) Decode(reader io.Reader, value interface{}) error {
+	var document yaml.Node
+	if err := yaml.NewDecoder(reader).Decode(&document); err != nil {
+		return err
+	}
+
+	tree, err := yamlTree(&document, 0)
+	if err != nil {
+		return err
+	}
+	return assignTree(tree, reflect.ValueOf(value))
+}
+
+const maxYAMLDepth = 64
+
+func yamlTree(node *yaml.Node, depth int) (interface{}, error) {
+	if depth > maxYAMLDepth {
+		return nil, fmt.Errorf("yaml document is nested too deeply")
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, io.EOF
+		}
+		return yamlTree(node.Content[0], depth)
+	case yaml.AliasNode:
+		return nil, fmt.Errorf("yaml line %d: aliases are not supported", node.Line)
+	case yaml.MappingNode:
+		object := newOrderedMap()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("yaml line %d: mapping keys must be scalars", key.Line)
+			}
+			value, err := yamlTree(node.Content[i+1], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key.Value, value)
+		}
+		return object, nil
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := yamlTree(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var boolean bool
+		err := node.Decode(&boolean)
+		return boolean, err
+	case "!!int":
+		var number interface{}
+		if err := node.Decode(&number); err != nil {
+			return nil, err
+		}
+		return json.Number(fmt.Sprint(number)), nil
+	case "!!float":
+		var number float64
+		err := node.Decode(&number)
+		return json.Number(strconv.FormatFloat(number, 'g', -1, 64)), err
+	default:
+		return node.Value, nil
+	}
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"project-restful-api/model/web"
+)
+
+func ToEnvelopeResponse(response web.WebResponse) web.EnvelopeResponse {
+	if response.Code < http.StatusBadRequest {
+		return web.EnvelopeResponse{Data: response.Data}
+	}
+
+	envelopeError := &web.EnvelopeError{
+		Code:   response.Code,
+		Status: response.Status,
+	}
+	if message, ok := response.Data.(string); ok {
+		envelopeError.Message = message
+	} else {
+		envelopeError.Details = response.Data
+	}
+	return web.EnvelopeResponse{Error: envelopeError}
+}
+
+type EnvelopeResponseWriter struct {
+	http.ResponseWriter
+}
+
+func NewEnvelopeResponseWriter(writer http.ResponseWriter) *EnvelopeResponseWriter {
+	return &EnvelopeResponseWriter{ResponseWriter: writer}
+}
+
+func (writer *EnvelopeResponseWriter) WriteBody(value interface{}) error {
+	if webResponse, ok := value.(web.WebResponse); ok {
+		if codec, ok := NegotiatedCodec(writer.ResponseWriter); !ok || codec.Format() != "jsonapi" {
+			value = ToEnvelopeResponse(webResponse)
+		}
+	}
+	WriteToResponseBody(writer.ResponseWriter, value)
+	return nil
+}
+
+func (writer *EnvelopeResponseWriter) Flush() {
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *EnvelopeResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return Hijack(writer.ResponseWriter)
+}
+
+func (writer *EnvelopeResponseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
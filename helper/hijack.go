@@ -0,0 +1,15 @@
+package helper
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+func Hijack(writer http.ResponseWriter) (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hijacker.Hijack()
+}
@@ -2,16 +2,91 @@ package helper
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
 func ReadFromRequestBody(request *http.Request, result interface{}) {
+	decodeRequestBody(request, result, false)
+}
+
+func ReadFromRequestBodyStrict(request *http.Request, result interface{}) {
+	decodeRequestBody(request, result, true)
+}
+
+func decodeRequestBody(request *http.Request, result interface{}, disallowUnknownFields bool) {
+	codec, ok := DefaultCodecs.ForContentType(request.Header.Get("Content-Type"))
+	if !ok {
+		panic(NewRequestBodyError(http.StatusUnsupportedMediaType, "content type must be one of "+strings.Join(DefaultCodecs.MediaTypes(), ", ")))
+	}
+	if codec.Format() != "json" {
+		err := codec.Decode(request.Body, result)
+		if err != nil {
+			panic(requestBodyError(err, nil))
+		}
+		return
+	}
+
 	decoder := json.NewDecoder(request.Body)
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
 	err := decoder.Decode(result)
-	PanicIfError(err)
+	if err != nil {
+		panic(requestBodyError(err, decoder))
+	}
+
+	err = decoder.Decode(&struct{}{})
+	if err != io.EOF {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			panic(requestBodyError(err, decoder))
+		}
+		panic(NewRequestBodyError(http.StatusBadRequest, "request body must contain a single JSON value"))
+	}
+}
+
+func requestBodyError(err error, decoder *json.Decoder) RequestBodyError {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	var maxBytesError *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesError):
+		return NewRequestBodyTooLargeError(maxBytesError.Limit)
+	case decoder == nil && errors.Is(err, io.EOF):
+		return NewRequestBodyError(http.StatusBadRequest, "request body must not be empty")
+	case decoder == nil:
+		return NewRequestBodyError(http.StatusBadRequest, "request body is malformed: "+err.Error())
+	case errors.As(err, &syntaxError):
+		return NewRequestBodyError(http.StatusBadRequest, fmt.Sprintf("request body contains malformed JSON at offset %d: %s", syntaxError.Offset, syntaxError.Error()))
+	case errors.As(err, &typeError):
+		return NewRequestBodyError(http.StatusBadRequest, fmt.Sprintf("request body field %q must be %s at offset %d", typeError.Field, typeError.Type, typeError.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewRequestBodyError(http.StatusBadRequest, fmt.Sprintf("request body contains malformed JSON at offset %d: unexpected end of input", decoder.InputOffset()))
+	case errors.Is(err, io.EOF):
+		return NewRequestBodyError(http.StatusBadRequest, "request body must not be empty")
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return NewRequestBodyError(http.StatusBadRequest, fmt.Sprintf("request body contains unknown field %s at offset %d", strings.TrimPrefix(err.Error(), "json: unknown field "), decoder.InputOffset()))
+	default:
+		return NewRequestBodyError(http.StatusBadRequest, err.Error())
+	}
+}
+
+type BodyWriter interface {
+	WriteBody(value interface{}) error
 }
 
 func WriteToResponseBody(writer http.ResponseWriter, response interface{}) {
+	if bodyWriter, ok := writer.(BodyWriter); ok {
+		err := bodyWriter.WriteBody(response)
+		PanicIfError(err)
+		return
+	}
+
 	writer.Header().Add("Content-Type", "application/json")
 	encoder := json.NewEncoder(writer)
 	err := encoder.Encode(response)
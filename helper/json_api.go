@@ -0,0 +1,286 @@
+package helper
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/url"
+	"project-restful-api/model/web"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonApiVersion         = "1.1"
+	defaultJsonApiPageSize = 20
+	maxJsonApiPageSize     = 100
+)
+
+type JsonApiPage struct {
+	Number int
+	Size   int
+}
+
+func ParseJsonApiPage(request *http.Request) JsonApiPage {
+	query := request.URL.Query()
+	page := JsonApiPage{
+		Number: pageParameter(query, "page[number]"),
+		Size:   pageParameter(query, "page[size]"),
+	}
+	if page.Size > maxJsonApiPageSize {
+		panic(BindError{Source: "query", Field: "page[size]", Message: "must not be greater than " + strconv.Itoa(maxJsonApiPageSize)})
+	}
+	if page.Number > 0 && page.Size == 0 {
+		page.Size = defaultJsonApiPageSize
+	}
+	if page.Size > 0 && page.Number == 0 {
+		page.Number = 1
+	}
+	return page
+}
+
+func pageParameter(query url.Values, name string) int {
+	value := query.Get(name)
+	if value == "" {
+		return 0
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		panic(BindError{Source: "query", Field: name, Message: "must be a positive integer"})
+	}
+	return number
+}
+
+func ToJsonApiDocument(response web.WebResponse) (web.JsonApiDocument, error) {
+	document := web.JsonApiDocument{JsonApi: web.JsonApiVersion{Version: jsonApiVersion}}
+	if response.Code >= http.StatusBadRequest {
+		document.Errors = []web.JsonApiError{toJsonApiError(response)}
+		return document, nil
+	}
+
+	document.Meta = map[string]interface{}{"status": response.Status}
+	if response.Data != nil {
+		tree, err := toTree(response.Data)
+		if err != nil {
+			return document, err
+		}
+		document.Meta["data"] = tree
+	}
+	return document, nil
+}
+
+func toJsonApiError(response web.WebResponse) web.JsonApiError {
+	jsonApiError := web.JsonApiError{
+		Status: strconv.Itoa(response.Code),
+		Title:  response.Status,
+	}
+	if detail, ok := response.Data.(string); ok {
+		jsonApiError.Detail = detail
+	} else {
+		jsonApiError.Meta = response.Data
+	}
+	return jsonApiError
+}
+
+type JsonApiResponseWriter struct {
+	http.ResponseWriter
+	Request   *http.Request
+	Type      string
+	Relations Relations
+	Page      JsonApiPage
+}
+
+func NewJsonApiResponseWriter(writer http.ResponseWriter, request *http.Request, resourceType string, relations Relations, page JsonApiPage) *JsonApiResponseWriter {
+	return &JsonApiResponseWriter{
+		ResponseWriter: writer,
+		Request:        request,
+		Type:           resourceType,
+		Relations:      relations,
+		Page:           page,
+	}
+}
+
+func (writer *JsonApiResponseWriter) WriteBody(value interface{}) error {
+	if webResponse, ok := value.(web.WebResponse); ok {
+		document, err := writer.document(webResponse)
+		if err != nil {
+			return err
+		}
+		value = document
+	}
+	WriteToResponseBody(writer.ResponseWriter, value)
+	return nil
+}
+
+func (writer *JsonApiResponseWriter) Flush() {
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *JsonApiResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return Hijack(writer.ResponseWriter)
+}
+
+func (writer *JsonApiResponseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
+
+func (writer *JsonApiResponseWriter) document(response web.WebResponse) (web.JsonApiDocument, error) {
+	if response.Code >= http.StatusBadRequest || response.Data == nil {
+		return ToJsonApiDocument(response)
+	}
+
+	tree, err := toTree(response.Data)
+	if err != nil {
+		return web.JsonApiDocument{}, err
+	}
+
+	document := web.JsonApiDocument{
+		JsonApi: web.JsonApiVersion{Version: jsonApiVersion},
+		Links:   map[string]string{"self": writer.Request.URL.RequestURI()},
+	}
+	included := &jsonApiIncluded{seen: map[string]bool{}}
+	switch tree := tree.(type) {
+	case *orderedMap:
+		document.Data = writer.resource(tree, included)
+	case []interface{}:
+		document.Meta = map[string]interface{}{"total": len(tree)}
+		resources := []web.JsonApiResource{}
+		for _, item := range writer.paginate(tree, document.Links) {
+			if object, ok := item.(*orderedMap); ok {
+				resources = append(resources, writer.resource(object, included))
+			}
+		}
+		document.Data = resources
+	default:
+		return ToJsonApiDocument(response)
+	}
+	document.Included = included.resources
+	return document, nil
+}
+
+func (writer *JsonApiResponseWriter) resource(object *orderedMap, included *jsonApiIncluded) web.JsonApiResource {
+	id := scalarString(object.Values["id"])
+	attributes := newOrderedMap()
+	relationships := map[string]web.JsonApiRelationship{}
+	for _, key := range object.Keys {
+		switch {
+		case key == "id":
+		case writer.hasRelation(key):
+			relationships[key] = web.JsonApiRelationship{Data: included.link(writer.Relations[key].Type, object.Values[key])}
+		default:
+			attributes.Set(key, object.Values[key])
+		}
+	}
+
+	resource := web.JsonApiResource{
+		Type:  writer.Type,
+		Id:    id,
+		Links: map[string]string{"self": writer.collectionPath() + "/" + id},
+	}
+	if len(attributes.Keys) > 0 {
+		resource.Attributes = attributes
+	}
+	if len(relationships) > 0 {
+		resource.Relationships = relationships
+	}
+	return resource
+}
+
+func (writer *JsonApiResponseWriter) hasRelation(name string) bool {
+	_, ok := writer.Relations[name]
+	return ok
+}
+
+func (writer *JsonApiResponseWriter) collectionPath() string {
+	path := writer.Request.URL.Path
+	if index := strings.LastIndex(path, "/"+writer.Type); index >= 0 {
+		return path[:index+len(writer.Type)+1]
+	}
+	return path
+}
+
+func (writer *JsonApiResponseWriter) paginate(list []interface{}, links map[string]string) []interface{} {
+	if writer.Page.Size == 0 {
+		return list
+	}
+
+	size := writer.Page.Size
+	last := (len(list) + size - 1) / size
+	if last == 0 {
+		last = 1
+	}
+	links["first"] = writer.pageLink(1)
+	links["last"] = writer.pageLink(last)
+	if writer.Page.Number > 1 {
+		previous := writer.Page.Number - 1
+		if previous > last {
+			previous = last
+		}
+		links["prev"] = writer.pageLink(previous)
+	}
+	if writer.Page.Number < last {
+		links["next"] = writer.pageLink(writer.Page.Number + 1)
+	}
+
+	start := (writer.Page.Number - 1) * size
+	if start > len(list) {
+		start = len(list)
+	}
+	end := start + size
+	if end > len(list) {
+		end = len(list)
+	}
+	return list[start:end]
+}
+
+func (writer *JsonApiResponseWriter) pageLink(number int) string {
+	query := writer.Request.URL.Query()
+	query.Set("page[number]", strconv.Itoa(number))
+	query.Set("page[size]", strconv.Itoa(writer.Page.Size))
+	return writer.Request.URL.Path + "?" + query.Encode()
+}
+
+type jsonApiIncluded struct {
+	seen      map[string]bool
+	resources []web.JsonApiResource
+}
+
+func (included *jsonApiIncluded) link(resourceType string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case *orderedMap:
+		return included.add(resourceType, value)
+	case []interface{}:
+		identifiers := []web.JsonApiResourceIdentifier{}
+		for _, item := range value {
+			if object, ok := item.(*orderedMap); ok {
+				identifiers = append(identifiers, included.add(resourceType, object))
+			}
+		}
+		return identifiers
+	default:
+		return nil
+	}
+}
+
+func (included *jsonApiIncluded) add(resourceType string, object *orderedMap) web.JsonApiResourceIdentifier {
+	identifier := web.JsonApiResourceIdentifier{Type: resourceType, Id: scalarString(object.Values["id"])}
+	if included.seen[identifier.Type+"/"+identifier.Id] {
+		return identifier
+	}
+	included.seen[identifier.Type+"/"+identifier.Id] = true
+
+	attributes := newOrderedMap()
+	for _, key := range object.Keys {
+		if key != "id" {
+			attributes.Set(key, object.Values[key])
+		}
+	}
+	included.resources = append(included.resources, web.JsonApiResource{
+		Type:       identifier.Type,
+		Id:         identifier.Id,
+		Attributes: attributes,
+	})
+	return identifier
+}
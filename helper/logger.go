@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+)
+
+var Logger = log.New(os.Stdout, "", 0)
+
+func LogJSON(entry map[string]interface{}) {
+	if _, ok := entry["time"]; !ok {
+		entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		Logger.Printf(`{"level":"error","message":%q}`, err.Error())
+		return
+	}
+	Logger.Println(string(line))
+}
+
+func LogInfo(message string) {
+	LogJSON(map[string]interface{}{"level": "info", "message": message})
+}
+
+func LogError(message string, err error) {
+	LogJSON(map[string]interface{}{"level": "error", "message": message, "error": err.Error()})
+}
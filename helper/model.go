@@ -19,3 +19,114 @@ func ToCategoryResponses(categories []domain.Category) []web.CategoryResponse {
 	}
 	return categoryResponses
 }
+
+func ToTodoResponses(todos []domain.Todo) []web.TodoResponse {
+	todoResponses := []web.TodoResponse{}
+	for _, todo := range todos {
+		todoResponses = append(todoResponses, web.TodoResponse{
+			Id:    todo.Id,
+			Title: todo.Title,
+			Done:  todo.Done,
+		})
+	}
+	return todoResponses
+}
+
+func ToAuthFailureResponses(failures []domain.AuthFailure) []web.AuthFailureResponse {
+	var authFailureResponses []web.AuthFailureResponse
+	for _, failure := range failures {
+		authFailureResponses = append(authFailureResponses, web.AuthFailureResponse{
+			Time:      failure.Time,
+			ClientIP:  failure.ClientIP,
+			KeyPrefix: failure.KeyPrefix,
+			Method:    failure.Method,
+			Path:      failure.Path,
+			Reason:    failure.Reason,
+		})
+	}
+	return authFailureResponses
+}
+
+func ToUserResponse(user domain.User) web.UserResponse {
+	return web.UserResponse{
+		Id:          user.Id,
+		Username:    user.Username,
+		Role:        user.Role,
+		TotpEnabled: user.TotpEnabled,
+	}
+}
+
+func ToQueryStatResponses(stats []domain.QueryStat) []web.QueryStatResponse {
+	var queryStatResponses []web.QueryStatResponse
+	for _, stat := range stats {
+		totalMs := float64(stat.TotalTime.Microseconds()) / 1000
+		queryStatResponses = append(queryStatResponses, web.QueryStatResponse{
+			Query:        stat.Query,
+			Count:        stat.Count,
+			SlowCount:    stat.SlowCount,
+			TotalMs:      totalMs,
+			MeanMs:       totalMs / float64(stat.Count),
+			MaxMs:        float64(stat.MaxTime.Microseconds()) / 1000,
+			LastExecuted: stat.LastExecuted,
+		})
+	}
+	return queryStatResponses
+}
+
+func ToWebhookResponse(webhook domain.Webhook) web.WebhookResponse {
+	return web.WebhookResponse{
+		Id:                  webhook.Id,
+		Url:                 webhook.Url,
+		EventTypes:          webhook.EventTypes,
+		Active:              webhook.Active,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt,
+	}
+}
+
+func ToWebhookResponses(webhooks []domain.Webhook) []web.WebhookResponse {
+	var webhookResponses []web.WebhookResponse
+	for _, webhook := range webhooks {
+		webhookResponses = append(webhookResponses, ToWebhookResponse(webhook))
+	}
+	return webhookResponses
+}
+
+func ToWebhookDeliveryResponse(delivery domain.WebhookDelivery, attempts []domain.WebhookAttempt) web.WebhookDeliveryResponse {
+	deliveryResponse := web.WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		AttemptCount:   delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		Attempts:       []web.WebhookAttemptResponse{},
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		deliveryResponse.NextAttemptAt = &nextAttemptAt
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		deliveredAt := delivery.DeliveredAt
+		deliveryResponse.DeliveredAt = &deliveredAt
+	}
+	for _, attempt := range attempts {
+		deliveryResponse.Attempts = append(deliveryResponse.Attempts, web.WebhookAttemptResponse{
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  float64(attempt.Duration.Microseconds()) / 1000,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+	return deliveryResponse
+}
+
+func ToWebhookDeliveryResponses(deliveries []domain.WebhookDelivery, attempts map[int][]domain.WebhookAttempt) []web.WebhookDeliveryResponse {
+	var deliveryResponses []web.WebhookDeliveryResponse
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, ToWebhookDeliveryResponse(delivery, attempts[delivery.Id]))
+	}
+	return deliveryResponses
+}
@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+type NegotiatedResponseWriter struct {
+	http.ResponseWriter
+	Codec       Codec
+	status      int
+	wroteHeader bool
+}
+
+func NewNegotiatedResponseWriter(writer http.ResponseWriter, codec Codec) *NegotiatedResponseWriter {
+	return &NegotiatedResponseWriter{ResponseWriter: writer, Codec: codec}
+}
+
+func (writer *NegotiatedResponseWriter) WriteHeader(status int) {
+	if writer.status == 0 {
+		writer.status = status
+	}
+}
+
+func (writer *NegotiatedResponseWriter) Write(bytes []byte) (int, error) {
+	writer.writePendingHeader()
+	return writer.ResponseWriter.Write(bytes)
+}
+
+func (writer *NegotiatedResponseWriter) WriteBody(value interface{}) error {
+	writer.Header().Set("Content-Type", writer.Codec.MediaTypes()[0])
+	writer.writePendingHeader()
+	return writer.Codec.Encode(writer.ResponseWriter, value)
+}
+
+func (writer *NegotiatedResponseWriter) Flush() {
+	writer.writePendingHeader()
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *NegotiatedResponseWriter) Close() {
+	if writer.status != 0 {
+		writer.writePendingHeader()
+	}
+}
+
+func (writer *NegotiatedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	writer.wroteHeader = true
+	return Hijack(writer.ResponseWriter)
+}
+
+func (writer *NegotiatedResponseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
+
+func (writer *NegotiatedResponseWriter) writePendingHeader() {
+	if writer.wroteHeader {
+		return
+	}
+	writer.wroteHeader = true
+	if writer.status == 0 {
+		writer.status = http.StatusOK
+	}
+	writer.ResponseWriter.WriteHeader(writer.status)
+}
+
+func NegotiatedCodec(writer http.ResponseWriter) (Codec, bool) {
+	for {
+		switch current := writer.(type) {
+		case *NegotiatedResponseWriter:
+			return current.Codec, true
+		case interface{ Unwrap() http.ResponseWriter }:
+			writer = current.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type RequestBodyError struct {
+	Status  int
+	Message string
+}
+
+func NewRequestBodyError(status int, message string) RequestBodyError {
+	return RequestBodyError{Status: status, Message: message}
+}
+
+func (err RequestBodyError) Error() string {
+	return err.Message
+}
+
+func NewRequestBodyTooLargeError(limit int64) RequestBodyError {
+	return NewRequestBodyError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not be larger than %d bytes", limit))
+}
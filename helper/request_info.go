@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+)
+
+type requestInfoContextKey struct{}
+
+type originalPathContextKey struct{}
+
+type RequestInfo struct {
+	RequestId string
+	TraceId   string
+	Route     string
+	Principal string
+	ClientIP  string
+}
+
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+func ValidRequestId(requestId string) bool {
+	return requestIdPattern.MatchString(requestId)
+}
+
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoContextKey{}, info)
+}
+
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, ok := ctx.Value(requestInfoContextKey{}).(*RequestInfo)
+	if !ok {
+		return &RequestInfo{}
+	}
+	return info
+}
+
+func RequestId(ctx context.Context) string {
+	return RequestInfoFromContext(ctx).RequestId
+}
+
+func WithOriginalPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, originalPathContextKey{}, path)
+}
+
+// OriginalPath returns the path the client sent, before any internal rewrite
+// such as routing an unversioned resource to its default version.
+func OriginalPath(request *http.Request) string {
+	if path, ok := request.Context().Value(originalPathContextKey{}).(string); ok {
+		return path
+	}
+	return request.URL.Path
+}
@@ -0,0 +1,189 @@
+package helper
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"project-restful-api/model/web"
+	"sort"
+	"strings"
+)
+
+type RelationLoader func(ctx context.Context, ids []int) map[int]interface{}
+
+// Relation describes an embeddable relation: Type is the JSON:API type of
+// the related resources and Load fetches them for a batch of resource ids.
+type Relation struct {
+	Type string
+	Load RelationLoader
+}
+
+type Relations map[string]Relation
+
+type ResponseShape struct {
+	Fields  []string
+	Include []string
+}
+
+func ParseResponseShape(request *http.Request, resource interface{}, relations Relations) ResponseShape {
+	query := request.URL.Query()
+	shape := ResponseShape{
+		Fields:  splitQueryList(query["fields"]),
+		Include: splitQueryList(query["include"]),
+	}
+
+	known := map[string]bool{}
+	if tree, err := toTree(resource); err == nil {
+		if object, ok := tree.(*orderedMap); ok {
+			for _, key := range object.Keys {
+				known[key] = true
+			}
+		}
+	}
+	for _, field := range shape.Fields {
+		if _, ok := relations[field]; !known[field] && !ok {
+			panic(BindError{Source: "query", Field: "fields", Message: "contains unknown field " + field})
+		}
+	}
+	for _, include := range shape.Include {
+		if _, ok := relations[include]; !ok {
+			panic(BindError{Source: "query", Field: "include", Message: "contains unknown relation " + include + ", must be one of [" + strings.Join(relations.Names(), ", ") + "]"})
+		}
+	}
+	return shape
+}
+
+func (relations Relations) Names() []string {
+	names := make([]string, 0, len(relations))
+	for name := range relations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (shape ResponseShape) IsEmpty() bool {
+	return len(shape.Fields) == 0 && len(shape.Include) == 0
+}
+
+func (shape ResponseShape) Apply(ctx context.Context, data interface{}, relations Relations) (interface{}, error) {
+	tree, err := toTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*orderedMap
+	switch tree := tree.(type) {
+	case *orderedMap:
+		objects = []*orderedMap{tree}
+	case []interface{}:
+		for _, item := range tree {
+			if object, ok := item.(*orderedMap); ok {
+				objects = append(objects, object)
+			}
+		}
+	default:
+		return data, nil
+	}
+
+	if len(shape.Include) > 0 {
+		ids := resourceIds(objects)
+		for _, include := range shape.Include {
+			loaded := relations[include].Load(ctx, ids)
+			for _, object := range objects {
+				id, _ := resourceId(object)
+				object.Set(include, loaded[id])
+			}
+		}
+	}
+
+	if len(shape.Fields) > 0 {
+		keep := append(append([]string{}, shape.Fields...), shape.Include...)
+		for _, object := range objects {
+			object.Project(keep)
+		}
+	}
+	return tree, nil
+}
+
+type ShapedResponseWriter struct {
+	http.ResponseWriter
+	Context   context.Context
+	Shape     ResponseShape
+	Relations Relations
+}
+
+func NewShapedResponseWriter(writer http.ResponseWriter, ctx context.Context, shape ResponseShape, relations Relations) *ShapedResponseWriter {
+	return &ShapedResponseWriter{
+		ResponseWriter: writer,
+		Context:        ctx,
+		Shape:          shape,
+		Relations:      relations,
+	}
+}
+
+func (writer *ShapedResponseWriter) WriteBody(value interface{}) error {
+	if webResponse, ok := value.(web.WebResponse); ok && webResponse.Code < http.StatusBadRequest && webResponse.Data != nil {
+		data, err := writer.Shape.Apply(writer.Context, webResponse.Data, writer.Relations)
+		if err != nil {
+			return err
+		}
+		webResponse.Data = data
+		value = webResponse
+	}
+	WriteToResponseBody(writer.ResponseWriter, value)
+	return nil
+}
+
+func (writer *ShapedResponseWriter) Flush() {
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *ShapedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return Hijack(writer.ResponseWriter)
+}
+
+func (writer *ShapedResponseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
+
+func resourceIds(objects []*orderedMap) []int {
+	seen := map[int]bool{}
+	var ids []int
+	for _, object := range objects {
+		id, ok := resourceId(object)
+		if ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func resourceId(object *orderedMap) (int, bool) {
+	number, ok := object.Values["id"].(json.Number)
+	if !ok {
+		return 0, false
+	}
+	id, err := number.Int64()
+	return int(id), err == nil
+}
+
+func splitQueryList(values []string) []string {
+	seen := map[string]bool{}
+	var list []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" && !seen[item] {
+				seen[item] = true
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+func HmacSHA256Hex(secret string, message []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(message)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func CanonicalRequest(method string, path string, query url.Values, body []byte, timestamp string, nonce string) string {
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		query.Encode(),
+		SHA256Hex(body),
+		timestamp,
+		nonce,
+	}, "\n")
+}
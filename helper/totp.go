@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	TotpDigits = 6
+	TotpPeriod = 30 * time.Second
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func RandomBytes(size int) []byte {
+	bytes := make([]byte, size)
+	_, err := rand.Read(bytes)
+	PanicIfError(err)
+	return bytes
+}
+
+func RandomToken(size int) string {
+	return hex.EncodeToString(RandomBytes(size))
+}
+
+func GenerateTotpSecret() string {
+	return totpEncoding.EncodeToString(RandomBytes(20))
+}
+
+func TotpProvisioningUri(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(int(TotpPeriod.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+func TotpCode(secret string, at time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(at.Unix()/int64(TotpPeriod.Seconds()))), nil
+}
+
+func VerifyTotp(secret string, code string, at time.Time) bool {
+	_, ok := VerifyTotpStep(secret, code, at)
+	return ok
+}
+
+func VerifyTotpStep(secret string, code string, at time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	counter := at.Unix() / int64(TotpPeriod.Seconds())
+	for _, skew := range []int64{0, -1, 1} {
+		expected := hotp(key, uint64(counter+skew))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + skew, true
+		}
+	}
+	return 0, false
+}
+
+func hotp(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < TotpDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", TotpDigits, value%modulo)
+}
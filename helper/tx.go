@@ -1,15 +1,23 @@
 package helper
 
-import "database/sql"
+import (
+	"database/sql"
+	"project-restful-api/metrics"
+)
 
-func CommitOrRollback(tx *sql.Tx) {
+func CommitOrRollback(tx *sql.Tx, afterCommit ...func()) {
 	err := recover()
 	if err != nil {
 		errorRollback := tx.Rollback()
 		PanicIfError(errorRollback)
+		metrics.DBTransactionsTotal.Inc("rollback")
 		panic(err)
 	} else {
 		errorCommit := tx.Commit()
 		PanicIfError(errorCommit)
+		metrics.DBTransactionsTotal.Inc("commit")
+		for _, callback := range afterCommit {
+			callback()
+		}
 	}
 }
@@ -4,29 +4,78 @@
 package main
 
 import (
-	"net/http"
 	"project-restful-api/app"
 	"project-restful-api/controller"
+	"project-restful-api/event"
 	"project-restful-api/middleware"
 	"project-restful-api/repository"
 	"project-restful-api/service"
+	"project-restful-api/webhook"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
-	"github.com/julienschmidt/httprouter"
 )
 
-func InitializeServer() *http.Server {
+func InitializeApplication() *Application {
 	wire.Build(
+		app.NewServerConfig,
+		app.NewReadiness,
+		app.NewBuildInfo,
 		app.NewDB,
 		validator.New,
+		app.NewQueryLogConfig,
+		repository.NewQueryLog,
 		repository.NewCategoryRepository,
+		repository.NewTodoRepository,
+		app.NewEventBroker,
+		wire.Bind(new(event.Publisher), new(*event.Broker)),
+		repository.NewWebhookRepository,
+		repository.NewWebhookDeliveryRepository,
+		app.NewWebhookConfig,
+		service.NewWebhookService,
+		wire.Bind(new(service.WebhookQueue), new(service.WebhookService)),
+		wire.Bind(new(webhook.Store), new(service.WebhookService)),
+		controller.NewWebhookController,
+		webhook.NewDispatcher,
 		service.NewCategoryService,
 		controller.NewCategoryController,
-		app.NewRouter,
-		wire.Bind(new(http.Handler), new(*httprouter.Router)),
+		controller.NewGraphqlSchema,
+		app.NewGraphqlLimits,
+		controller.NewGraphqlController,
+		controller.NewRpcController,
+		app.NewEventStreamConfig,
+		controller.NewEventController,
+		app.NewWebSocketConfig,
+		controller.NewWebSocketController,
+		repository.NewUserRepository,
+		repository.NewSessionRepository,
+		service.NewUserService,
+		controller.NewAuthController,
+		service.NewHealthService,
+		wire.Bind(new(service.ReadinessProbe), new(*app.Readiness)),
+		controller.NewHealthController,
+		app.NewApiKeys,
+		app.NewTwoFactorPolicy,
+		app.NewTrustedProxies,
+		app.NewAuthGuardConfig,
+		app.NewAuthAlertHook,
+		middleware.NewAuthGuard,
+		app.NewHmacConfig,
+		middleware.NewHmacVerifier,
 		middleware.NewAuthMiddleware,
+		controller.NewAdminController,
+		middleware.NewMemoryRateLimitStore,
+		middleware.NewRateLimitMiddleware,
+		app.NewRateLimits,
+		app.NewApiVersions,
+		app.NewTracingConfig,
+		app.NewTracer,
+		app.NewCorsConfig,
+		app.NewCompressionConfig,
+		app.NewRouter,
+		app.NewHandler,
 		NewServer,
+		NewApplication,
 	)
 	return nil
 }
@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const Version = "2.0"
+
+type Response struct {
+	Id     json.RawMessage
+	Result interface{}
+	Error  *Error
+}
+
+func (response *Response) MarshalJSON() ([]byte, error) {
+	id := response.Id
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if response.Error != nil {
+		return json.Marshal(struct {
+			JsonRpc string          `json:"jsonrpc"`
+			Error   *Error          `json:"error"`
+			Id      json.RawMessage `json:"id"`
+		}{Version, response.Error, id})
+	}
+	return json.Marshal(struct {
+		JsonRpc string          `json:"jsonrpc"`
+		Result  interface{}     `json:"result"`
+		Id      json.RawMessage `json:"id"`
+	}{Version, response.Result, id})
+}
+
+type Params struct {
+	raw   json.RawMessage
+	names []string
+}
+
+func (params Params) Decode(target interface{}) error {
+	raw := params.raw
+	if len(raw) == 0 {
+		return nil
+	}
+
+	if raw[0] == '[' {
+		var positional []json.RawMessage
+		if err := json.Unmarshal(raw, &positional); err != nil {
+			return NewError(CodeInvalidParams, "Invalid params", err.Error())
+		}
+		if len(positional) > len(params.names) {
+			return NewError(CodeInvalidParams, "Invalid params", "too many positional params")
+		}
+		named := map[string]json.RawMessage{}
+		for i, value := range positional {
+			named[params.names[i]] = value
+		}
+		encoded, err := json.Marshal(named)
+		if err != nil {
+			return NewError(CodeInvalidParams, "Invalid params", err.Error())
+		}
+		raw = encoded
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return NewError(CodeInvalidParams, "Invalid params", err.Error())
+	}
+	return nil
+}
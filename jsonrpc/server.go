@@ -0,0 +1,136 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+type Handler func(ctx context.Context, params Params) (interface{}, error)
+
+type RecoverFunc func(ctx context.Context, recovered interface{}) *Error
+
+type method struct {
+	paramNames []string
+	handler    Handler
+}
+
+type Server struct {
+	MaxBatchSize int
+	Recover      RecoverFunc
+	methods      map[string]method
+}
+
+func NewServer(maxBatchSize int) *Server {
+	return &Server{
+		MaxBatchSize: maxBatchSize,
+		methods:      map[string]method{},
+	}
+}
+
+func (server *Server) Register(name string, paramNames []string, handler Handler) {
+	server.methods[name] = method{paramNames: paramNames, handler: handler}
+}
+
+func (server *Server) Handle(ctx context.Context, body []byte) (interface{}, bool) {
+	body = bytes.TrimSpace(body)
+	if !json.Valid(body) {
+		return &Response{Error: NewError(CodeParseError, "Parse error", nil)}, true
+	}
+	if body[0] != '[' {
+		response := server.call(ctx, body)
+		return response, response != nil
+	}
+
+	var calls []json.RawMessage
+	if err := json.Unmarshal(body, &calls); err != nil {
+		return &Response{Error: NewError(CodeParseError, "Parse error", nil)}, true
+	}
+	if len(calls) == 0 {
+		return &Response{Error: NewError(CodeInvalidRequest, "Invalid Request", "batch must not be empty")}, true
+	}
+	if server.MaxBatchSize > 0 && len(calls) > server.MaxBatchSize {
+		return &Response{Error: NewError(CodeInvalidRequest, "Invalid Request", "batch must not contain more than "+strconv.Itoa(server.MaxBatchSize)+" calls")}, true
+	}
+
+	responses := []*Response{}
+	for _, call := range calls {
+		if response := server.call(ctx, call); response != nil {
+			responses = append(responses, response)
+		}
+	}
+	return responses, len(responses) > 0
+}
+
+func (server *Server) call(ctx context.Context, raw json.RawMessage) *Response {
+	var fields map[string]json.RawMessage
+	if raw[0] != '{' || json.Unmarshal(raw, &fields) != nil {
+		return &Response{Error: NewError(CodeInvalidRequest, "Invalid Request", "request must be an object")}
+	}
+
+	id, hasId := fields["id"]
+	if hasId && !validId(id) {
+		return &Response{Error: NewError(CodeInvalidRequest, "Invalid Request", "id must be a string, number or null")}
+	}
+
+	var version, name string
+	if json.Unmarshal(fields["jsonrpc"], &version) != nil || version != Version {
+		return &Response{Id: id, Error: NewError(CodeInvalidRequest, "Invalid Request", `jsonrpc must be exactly "2.0"`)}
+	}
+	if json.Unmarshal(fields["method"], &name) != nil || name == "" {
+		return &Response{Id: id, Error: NewError(CodeInvalidRequest, "Invalid Request", "method must be a non-empty string")}
+	}
+	params, hasParams := fields["params"]
+	if hasParams && (len(params) == 0 || (params[0] != '[' && params[0] != '{')) {
+		return &Response{Id: id, Error: NewError(CodeInvalidRequest, "Invalid Request", "params must be an array or an object")}
+	}
+
+	method, ok := server.methods[name]
+	if !ok {
+		if !hasId {
+			return nil
+		}
+		return &Response{Id: id, Error: NewError(CodeMethodNotFound, "Method not found", name)}
+	}
+
+	result, err := server.invoke(ctx, method, Params{raw: params, names: method.paramNames})
+	if !hasId {
+		return nil
+	}
+	if err != nil {
+		return &Response{Id: id, Error: toError(err)}
+	}
+	return &Response{Id: id, Result: result}
+}
+
+func (server *Server) invoke(ctx context.Context, method method, params Params) (result interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if server.Recover != nil {
+				err = server.Recover(ctx, recovered)
+			} else {
+				err = NewError(CodeInternalError, "Internal error", nil)
+			}
+		}
+	}()
+	return method.handler(ctx, params)
+}
+
+func validId(id json.RawMessage) bool {
+	switch {
+	case len(id) == 0:
+		return false
+	case id[0] == '"', id[0] == '-', id[0] >= '0' && id[0] <= '9':
+		return true
+	default:
+		return string(id) == "null"
+	}
+}
+
+func toError(err error) *Error {
+	if rpcError, ok := err.(*Error); ok {
+		return rpcError
+	}
+	return NewError(CodeInternalError, "Internal error", err.Error())
+}
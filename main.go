@@ -1,23 +1,128 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"project-restful-api/app"
+	"project-restful-api/event"
 	"project-restful-api/helper"
-	"project-restful-api/middleware"
+	"project-restful-api/repository"
+	"project-restful-api/tracing"
+	"project-restful-api/webhook"
+	"syscall"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+type Application struct {
+	Server    *http.Server
+	DB        *sql.DB
+	Readiness *app.Readiness
+	Tracer    *tracing.Tracer
+	Events    *event.Broker
+	Webhooks  *webhook.Dispatcher
+	Config    app.ServerConfig
+}
+
+func NewApplication(server *http.Server, db *sql.DB, readiness *app.Readiness, tracer *tracing.Tracer, events *event.Broker, webhooks *webhook.Dispatcher, config app.ServerConfig) *Application {
+	return &Application{
+		Server:    server,
+		DB:        db,
+		Readiness: readiness,
+		Tracer:    tracer,
+		Events:    events,
+		Webhooks:  webhooks,
+		Config:    config,
+	}
+}
 
-func NewServer(authMiddleware *middleware.AuthMiddleware) *http.Server {
+func NewServer(handler http.Handler, config app.ServerConfig) *http.Server {
 	return &http.Server{
-		Addr: "localhost:3000",
-		Handler: authMiddleware,
+		Addr:              config.Addr,
+		Handler:           handler,
+		ReadTimeout:       config.ReadTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+	}
+}
+
+func (application *Application) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", application.Server.Addr)
+	if err != nil {
+		application.Tracer.Shutdown(context.Background())
+		application.DB.Close()
+		return err
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- application.Server.Serve(listener)
+	}()
+	application.Readiness.SetReady(true)
+
+	webhooksCtx, stopWebhooks := context.WithCancel(context.Background())
+	webhooksDone := make(chan struct{})
+	go func() {
+		application.Webhooks.Run(webhooksCtx)
+		close(webhooksDone)
+	}()
+
+	select {
+	case err := <-serverErr:
+		application.Readiness.SetReady(false)
+		stopWebhooks()
+		<-webhooksDone
+		application.Tracer.Shutdown(context.Background())
+		application.DB.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	helper.LogInfo("shutting down, draining connections for up to " + application.Config.ShutdownTimeout.String())
+	application.Readiness.SetReady(false)
+	application.Events.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), application.Config.ShutdownTimeout)
+	defer cancel()
+
+	shutdownErr := application.Server.Shutdown(shutdownCtx)
+	if err := <-serverErr; shutdownErr == nil && !errors.Is(err, http.ErrServerClosed) {
+		shutdownErr = err
+	}
+
+	stopWebhooks()
+	select {
+	case <-webhooksDone:
+	case <-shutdownCtx.Done():
+	}
+
+	tracerErr := application.Tracer.Shutdown(shutdownCtx)
+	closeErr := application.DB.Close()
+	if shutdownErr != nil {
+		return shutdownErr
+	}
+	if tracerErr != nil {
+		return tracerErr
 	}
+	return closeErr
 }
+
 func main() {
-	server := InitializeServer()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	application := InitializeApplication()
+
+	err := repository.Migrate(ctx, application.DB)
+	helper.PanicIfError(err)
 
-	err := server.ListenAndServe()
+	err = application.Run(ctx)
 	helper.PanicIfError(err)
 }
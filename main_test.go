@@ -1,11 +1,14 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestMainTest(t *testing.T) {
-	server := InitializeServer()
+	application := InitializeApplication()
 
-	err := server.ListenAndServe()
+	err := application.Run(context.Background())
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"io"
+	"sync"
+)
+
+type counterValue struct {
+	labelValues []string
+	value       float64
+}
+
+type CounterVec struct {
+	mutex      sync.Mutex
+	Name       string
+	Help       string
+	LabelNames []string
+	values     map[string]*counterValue
+}
+
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		Name:       name,
+		Help:       help,
+		LabelNames: labelNames,
+		values:     make(map[string]*counterValue),
+	}
+}
+
+func (counter *CounterVec) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+func (counter *CounterVec) Add(value float64, labelValues ...string) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	key := labelKey(labelValues)
+	current, ok := counter.values[key]
+	if !ok {
+		current = &counterValue{labelValues: labelValues}
+		counter.values[key] = current
+	}
+	current.value += value
+}
+
+func (counter *CounterVec) Value(labelValues ...string) float64 {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	if current, ok := counter.values[labelKey(labelValues)]; ok {
+		return current.value
+	}
+	return 0
+}
+
+func (counter *CounterVec) Collect(writer io.Writer) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	writeHeader(writer, counter.Name, counter.Help, "counter")
+	for _, key := range sortedKeys(counter.values) {
+		current := counter.values[key]
+		writeSample(writer, counter.Name, counter.LabelNames, current.labelValues, current.value)
+	}
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import "io"
+
+type ValueFunc struct {
+	Name       string
+	Help       string
+	MetricType string
+	Value      func() float64
+}
+
+func NewGaugeFunc(name string, help string, value func() float64) *ValueFunc {
+	return &ValueFunc{Name: name, Help: help, MetricType: "gauge", Value: value}
+}
+
+func NewCounterFunc(name string, help string, value func() float64) *ValueFunc {
+	return &ValueFunc{Name: name, Help: help, MetricType: "counter", Value: value}
+}
+
+func (gauge *ValueFunc) Collect(writer io.Writer) {
+	writeHeader(writer, gauge.Name, gauge.Help, gauge.MetricType)
+	writeSample(writer, gauge.Name, nil, nil, gauge.Value())
+}
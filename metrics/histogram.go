@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"io"
+	"sync"
+)
+
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogramValue struct {
+	labelValues []string
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+type HistogramVec struct {
+	mutex      sync.Mutex
+	Name       string
+	Help       string
+	Buckets    []float64
+	LabelNames []string
+	values     map[string]*histogramValue
+}
+
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	return &HistogramVec{
+		Name:       name,
+		Help:       help,
+		Buckets:    buckets,
+		LabelNames: labelNames,
+		values:     make(map[string]*histogramValue),
+	}
+}
+
+func (histogram *HistogramVec) Observe(value float64, labelValues ...string) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	key := labelKey(labelValues)
+	current, ok := histogram.values[key]
+	if !ok {
+		current = &histogramValue{labelValues: labelValues, counts: make([]uint64, len(histogram.Buckets))}
+		histogram.values[key] = current
+	}
+	for i, bound := range histogram.Buckets {
+		if value <= bound {
+			current.counts[i]++
+		}
+	}
+	current.sum += value
+	current.count++
+}
+
+func (histogram *HistogramVec) Collect(writer io.Writer) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	writeHeader(writer, histogram.Name, histogram.Help, "histogram")
+	bucketLabels := append(append([]string{}, histogram.LabelNames...), "le")
+	for _, key := range sortedKeys(histogram.values) {
+		current := histogram.values[key]
+		for i, bound := range histogram.Buckets {
+			writeSample(writer, histogram.Name+"_bucket", bucketLabels, append(append([]string{}, current.labelValues...), formatFloat(bound)), float64(current.counts[i]))
+		}
+		writeSample(writer, histogram.Name+"_bucket", bucketLabels, append(append([]string{}, current.labelValues...), "+Inf"), float64(current.count))
+		writeSample(writer, histogram.Name+"_sum", histogram.LabelNames, current.labelValues, current.sum)
+		writeSample(writer, histogram.Name+"_count", histogram.LabelNames, current.labelValues, float64(current.count))
+	}
+}
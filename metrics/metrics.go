@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"database/sql"
+	"sync"
+)
+
+var (
+	HttpRequestsTotal = NewCounterVec(
+		"http_requests_total",
+		"Total number of HTTP requests by method, route pattern and status.",
+		"method", "route", "status",
+	)
+	HttpRequestDuration = NewHistogramVec(
+		"http_request_duration_seconds",
+		"HTTP request latency by method, route pattern and status.",
+		DefaultBuckets,
+		"method", "route", "status",
+	)
+	DBTransactionsTotal = NewCounterVec(
+		"db_transactions_total",
+		"Total number of database transactions by result.",
+		"result",
+	)
+	PanicsTotal = NewCounterVec(
+		"http_panics_total",
+		"Total number of unexpected panics answered with a 500 by panic type.",
+		"type",
+	)
+	WebhookAttemptsTotal = NewCounterVec(
+		"webhook_attempts_total",
+		"Total number of outgoing webhook delivery attempts by result.",
+		"result",
+	)
+)
+
+func init() {
+	DefaultRegistry.MustRegister(HttpRequestsTotal, HttpRequestDuration, DBTransactionsTotal, PanicsTotal, WebhookAttemptsTotal)
+}
+
+type dbStatsSource struct {
+	mutex sync.RWMutex
+	db    *sql.DB
+}
+
+func (source *dbStatsSource) stats() sql.DBStats {
+	source.mutex.RLock()
+	defer source.mutex.RUnlock()
+	return source.db.Stats()
+}
+
+func (source *dbStatsSource) set(db *sql.DB) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.db = db
+}
+
+var (
+	dbStatsMutex   sync.Mutex
+	dbStatsSources = map[*Registry]*dbStatsSource{}
+)
+
+// RegisterDBStats registers the connection pool gauges once per registry;
+// calling it again points the existing gauges at the new database.
+func RegisterDBStats(registry *Registry, db *sql.DB) {
+	dbStatsMutex.Lock()
+	defer dbStatsMutex.Unlock()
+
+	if source, ok := dbStatsSources[registry]; ok {
+		source.set(db)
+		return
+	}
+	source := &dbStatsSource{db: db}
+	dbStatsSources[registry] = source
+	registry.MustRegister(
+		NewGaugeFunc("db_max_open_connections", "Maximum number of open connections to the database.", func() float64 {
+			return float64(source.stats().MaxOpenConnections)
+		}),
+		NewGaugeFunc("db_open_connections", "The number of established connections both in use and idle.", func() float64 {
+			return float64(source.stats().OpenConnections)
+		}),
+		NewGaugeFunc("db_in_use_connections", "The number of connections currently in use.", func() float64 {
+			return float64(source.stats().InUse)
+		}),
+		NewGaugeFunc("db_idle_connections", "The number of idle connections.", func() float64 {
+			return float64(source.stats().Idle)
+		}),
+		NewCounterFunc("db_wait_count_total", "The total number of connections waited for.", func() float64 {
+			return float64(source.stats().WaitCount)
+		}),
+		NewCounterFunc("db_wait_duration_seconds_total", "The total time blocked waiting for a new connection.", func() float64 {
+			return source.stats().WaitDuration.Seconds()
+		}),
+	)
+}
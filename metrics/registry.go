@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Collector interface {
+	Collect(writer io.Writer)
+}
+
+type Registry struct {
+	mutex      sync.RWMutex
+	collectors []Collector
+}
+
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (registry *Registry) MustRegister(collectors ...Collector) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.collectors = append(registry.collectors, collectors...)
+}
+
+func (registry *Registry) Expose(writer io.Writer) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	buffered := bufio.NewWriter(writer)
+	for _, collector := range registry.collectors {
+		collector.Collect(buffered)
+	}
+	buffered.Flush()
+}
+
+func (registry *Registry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	registry.Expose(writer)
+}
+
+func writeHeader(writer io.Writer, name string, help string, metricType string) {
+	fmt.Fprintf(writer, "# HELP %s %s\n", name, strings.ReplaceAll(help, "\n", " "))
+	fmt.Fprintf(writer, "# TYPE %s %s\n", name, metricType)
+}
+
+func writeSample(writer io.Writer, name string, labelNames []string, labelValues []string, value float64) {
+	io.WriteString(writer, name)
+	if len(labelNames) > 0 {
+		io.WriteString(writer, "{")
+		for i, labelName := range labelNames {
+			if i > 0 {
+				io.WriteString(writer, ",")
+			}
+			fmt.Fprintf(writer, "%s=\"%s\"", labelName, escapeLabelValue(labelValues[i]))
+		}
+		io.WriteString(writer, "}")
+	}
+	fmt.Fprintf(writer, " %s\n", formatFloat(value))
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AccessLogMiddleware struct {
+	Handler        http.Handler
+	TrustedProxies helper.TrustedProxies
+}
+
+func NewAccessLogMiddleware(handler http.Handler, trustedProxies helper.TrustedProxies) *AccessLogMiddleware {
+	return &AccessLogMiddleware{
+		Handler:        handler,
+		TrustedProxies: trustedProxies,
+	}
+}
+
+func (middleware *AccessLogMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	start := time.Now()
+
+	requestId := request.Header.Get("X-Request-ID")
+	if !helper.ValidRequestId(requestId) {
+		requestId = helper.RandomToken(16)
+	}
+	writer.Header().Set("X-Request-ID", requestId)
+
+	info := &helper.RequestInfo{RequestId: requestId, ClientIP: helper.ClientIP(request, middleware.TrustedProxies)}
+	recorder := NewResponseRecorder(writer)
+	middleware.Handler.ServeHTTP(recorder, request.WithContext(helper.WithRequestInfo(request.Context(), info)))
+
+	if recorder.Status == 0 {
+		recorder.Status = http.StatusOK
+	}
+	helper.LogJSON(map[string]interface{}{
+		"level":      "info",
+		"message":    "request completed",
+		"request_id": requestId,
+		"trace_id":   info.TraceId,
+		"method":     request.Method,
+		"route":      info.Route,
+		"path":       request.URL.Path,
+		"status":     recorder.Status,
+		"bytes":      recorder.Bytes,
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		"principal":  info.Principal,
+		"client_ip":  info.ClientIP,
+	})
+}
+
+func Route(pattern string) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			helper.RequestInfoFromContext(request.Context()).Route = pattern
+			next(writer, request, params)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ApiVersionPolicy struct {
+	Version      string
+	DeprecatedAt time.Time
+	SunsetAt     time.Time
+	Successor    string
+	Envelope     bool
+}
+
+func ApiVersion(policy ApiVersionPolicy) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			header := writer.Header()
+			header.Set("API-Version", policy.Version)
+			if !policy.DeprecatedAt.IsZero() {
+				header.Set("Deprecation", "@"+strconv.FormatInt(policy.DeprecatedAt.Unix(), 10))
+			}
+			if !policy.SunsetAt.IsZero() {
+				header.Set("Sunset", policy.SunsetAt.UTC().Format(http.TimeFormat))
+			}
+			if policy.Successor != "" {
+				successor := strings.Replace(request.URL.Path, "/"+policy.Version+"/", "/"+policy.Successor+"/", 1)
+				header.Add("Link", "<"+successor+`>; rel="successor-version"`)
+			}
+			next(writer, request, params)
+		}
+	}
+}
+
+var vendorMediaTypePattern = regexp.MustCompile(`^application/vnd\.todolist\.(v[0-9]+)\+json$`)
+
+type ApiVersionMiddleware struct {
+	Handler        http.Handler
+	Prefix         string
+	Resources      []string
+	Versions       []ApiVersionPolicy
+	DefaultVersion string
+}
+
+func NewApiVersionMiddleware(handler http.Handler, resources []string, versions []ApiVersionPolicy, defaultVersion string) *ApiVersionMiddleware {
+	return &ApiVersionMiddleware{
+		Handler:        handler,
+		Prefix:         "/api",
+		Resources:      resources,
+		Versions:       versions,
+		DefaultVersion: defaultVersion,
+	}
+}
+
+func (middleware *ApiVersionMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	rest := strings.TrimPrefix(request.URL.Path, middleware.Prefix)
+	if rest == request.URL.Path {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	if policy, ok := middleware.pathVersion(rest); ok {
+		middleware.Handler.ServeHTTP(middleware.responseWriter(writer, policy), request)
+		return
+	}
+	if !middleware.isResource(rest) {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	version := middleware.DefaultVersion
+	if requested := RequestedApiVersion(request.Header.Get("Accept")); requested != "" {
+		if _, ok := middleware.policy(requested); !ok {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusNotAcceptable)
+			webResponse := web.WebResponse{
+				Code:   http.StatusNotAcceptable,
+				Status: "NOT ACCEPTABLE",
+				Data:   "api version " + requested + " is not supported",
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
+		version = requested
+	}
+
+	policy, _ := middleware.policy(version)
+	versioned := request.Clone(helper.WithOriginalPath(request.Context(), request.URL.Path))
+	versioned.URL.Path = middleware.Prefix + "/" + version + rest
+	versioned.URL.RawPath = ""
+	middleware.Handler.ServeHTTP(middleware.responseWriter(writer, policy), versioned)
+}
+
+func (middleware *ApiVersionMiddleware) responseWriter(writer http.ResponseWriter, policy ApiVersionPolicy) http.ResponseWriter {
+	if policy.Envelope {
+		return helper.NewEnvelopeResponseWriter(writer)
+	}
+	return writer
+}
+
+func (middleware *ApiVersionMiddleware) pathVersion(path string) (ApiVersionPolicy, bool) {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if len(segments) < 2 || !middleware.isResource("/"+segments[1]) {
+		return ApiVersionPolicy{}, false
+	}
+	return middleware.policy(segments[0])
+}
+
+func (middleware *ApiVersionMiddleware) isResource(path string) bool {
+	for _, resource := range middleware.Resources {
+		if path == "/"+resource || strings.HasPrefix(path, "/"+resource+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (middleware *ApiVersionMiddleware) policy(version string) (ApiVersionPolicy, bool) {
+	for _, policy := range middleware.Versions {
+		if policy.Version == version {
+			return policy, true
+		}
+	}
+	return ApiVersionPolicy{}, false
+}
+
+func RequestedApiVersion(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if matches := vendorMediaTypePattern.FindStringSubmatch(mediaType); matches != nil {
+			return matches[1]
+		}
+		if mediaType == "application/vnd.todolist+json" && params["version"] != "" {
+			return "v" + strings.TrimPrefix(params["version"], "v")
+		}
+	}
+	return ""
+}
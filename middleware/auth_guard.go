@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"project-restful-api/helper"
+	"project-restful-api/model/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+type AuthGuardConfig struct {
+	MaxFailures   int
+	FailureWindow time.Duration
+	BaseLockout   time.Duration
+	MaxLockout    time.Duration
+	KeyPrefixSize int
+	EventLogSize  int
+}
+
+type AuthAlert struct {
+	Subject     string
+	Failures    int
+	LockedUntil time.Time
+}
+
+type AuthAlertHook func(alert AuthAlert)
+
+type authAttempt struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+type AuthGuard struct {
+	mutex     sync.Mutex
+	Config    AuthGuardConfig
+	AlertHook AuthAlertHook
+	Now       func() time.Time
+	attempts  map[string]*authAttempt
+	events    []domain.AuthFailure
+	next      int
+	sweptAt   time.Time
+}
+
+func NewAuthGuard(config AuthGuardConfig, alertHook AuthAlertHook) *AuthGuard {
+	return &AuthGuard{
+		Config:    config,
+		AlertHook: alertHook,
+		Now:       time.Now,
+		attempts:  make(map[string]*authAttempt),
+		events:    make([]domain.AuthFailure, 0, config.EventLogSize),
+	}
+}
+
+func LogAuthAlert(alert AuthAlert) {
+	helper.LogJSON(map[string]interface{}{
+		"level":        "warn",
+		"message":      "authentication lockout",
+		"subject":      alert.Subject,
+		"failures":     alert.Failures,
+		"locked_until": alert.LockedUntil.Format(time.RFC3339),
+	})
+}
+
+func (guard *AuthGuard) IpSubject(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+func (guard *AuthGuard) KeySubject(clientIP string, apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key:" + clientIP + ":" + hex.EncodeToString(sum[:16])
+}
+
+func (guard *AuthGuard) KeyPrefix(apiKey string) string {
+	if len(apiKey) > guard.Config.KeyPrefixSize {
+		return apiKey[:guard.Config.KeyPrefixSize]
+	}
+	return apiKey
+}
+
+func (guard *AuthGuard) AccountSubject(clientIP string, username string) string {
+	return "account:" + clientIP + ":" + strings.ToLower(username)
+}
+
+func (guard *AuthGuard) LockedFor(subjects []string) time.Duration {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	now := guard.Now()
+	var remaining time.Duration
+	for _, subject := range subjects {
+		attempt, ok := guard.attempts[subject]
+		if ok && attempt.lockedUntil.After(now) && attempt.lockedUntil.Sub(now) > remaining {
+			remaining = attempt.lockedUntil.Sub(now)
+		}
+	}
+	return remaining
+}
+
+func (guard *AuthGuard) RecordSuccess(subjects []string) {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	for _, subject := range subjects {
+		delete(guard.attempts, subject)
+	}
+}
+
+func (guard *AuthGuard) RecordFailure(failure domain.AuthFailure, subjects []string) {
+	guard.mutex.Lock()
+	now := guard.Now()
+	failure.Time = now
+	guard.appendEvent(failure)
+
+	var alerts []AuthAlert
+	for _, subject := range subjects {
+		if subject == "" {
+			continue
+		}
+		attempt, ok := guard.attempts[subject]
+		if !ok || now.Sub(attempt.lastFailure) > guard.Config.FailureWindow {
+			attempt = &authAttempt{}
+			guard.attempts[subject] = attempt
+		}
+		attempt.failures++
+		attempt.lastFailure = now
+
+		if attempt.failures >= guard.Config.MaxFailures {
+			attempt.lockedUntil = now.Add(guard.lockout(attempt.failures))
+			alerts = append(alerts, AuthAlert{
+				Subject:     subject,
+				Failures:    attempt.failures,
+				LockedUntil: attempt.lockedUntil,
+			})
+		}
+	}
+	guard.sweep(now)
+	guard.mutex.Unlock()
+
+	if guard.AlertHook != nil {
+		for _, alert := range alerts {
+			guard.AlertHook(alert)
+		}
+	}
+}
+
+func (guard *AuthGuard) Events(limit int, clientIP string, keyPrefix string) []domain.AuthFailure {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	var events []domain.AuthFailure
+	for i := 0; i < len(guard.events) && len(events) < limit; i++ {
+		event := guard.events[(guard.next-1-i+len(guard.events))%len(guard.events)]
+		if clientIP != "" && event.ClientIP != clientIP {
+			continue
+		}
+		if keyPrefix != "" && event.KeyPrefix != keyPrefix {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func (guard *AuthGuard) lockout(failures int) time.Duration {
+	lockout := guard.Config.BaseLockout
+	for i := guard.Config.MaxFailures; i < failures && lockout < guard.Config.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > guard.Config.MaxLockout {
+		lockout = guard.Config.MaxLockout
+	}
+	return lockout
+}
+
+func (guard *AuthGuard) appendEvent(failure domain.AuthFailure) {
+	if guard.Config.EventLogSize == 0 {
+		return
+	}
+	if len(guard.events) < guard.Config.EventLogSize {
+		guard.events = append(guard.events, failure)
+	} else {
+		guard.events[guard.next] = failure
+	}
+	guard.next = (guard.next + 1) % guard.Config.EventLogSize
+}
+
+func (guard *AuthGuard) sweep(now time.Time) {
+	if now.Sub(guard.sweptAt) < time.Minute {
+		return
+	}
+	guard.sweptAt = now
+	for subject, attempt := range guard.attempts {
+		if now.Sub(attempt.lastFailure) > guard.Config.FailureWindow && now.After(attempt.lockedUntil) {
+			delete(guard.attempts, subject)
+		}
+	}
+}
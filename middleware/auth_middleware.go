@@ -1,29 +1,189 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"project-restful-api/exception"
 	"project-restful-api/helper"
-	"project-restful-api/model/web"
+	"project-restful-api/model/domain"
+	"project-restful-api/repository"
+	"project-restful-api/websocket"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	BearerSubprotocolPrefix = "bearer."
+	ApiKeySubprotocolPrefix = "apikey."
 )
 
+type principalContextKey struct{}
+
 type AuthMiddleware struct {
-	Handler http.Handler
+	ApiKeys           map[string]domain.ApiKey
+	SigningKeys       map[string]domain.ApiKey
+	SessionRepository repository.SessionRepository
+	TwoFactorPolicy   *TwoFactorPolicy
+	Guard             *AuthGuard
+	HmacVerifier      *HmacVerifier
+	TrustedProxies    helper.TrustedProxies
 }
 
-func NewAuthMiddleware(handler http.Handler) *AuthMiddleware {
-	return &AuthMiddleware{Handler: handler}
+func NewAuthMiddleware(apiKeys []domain.ApiKey, sessionRepository repository.SessionRepository, twoFactorPolicy *TwoFactorPolicy, guard *AuthGuard, hmacVerifier *HmacVerifier, trustedProxies helper.TrustedProxies) *AuthMiddleware {
+	keys := make(map[string]domain.ApiKey, len(apiKeys))
+	signingKeys := make(map[string]domain.ApiKey, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		if apiKey.Key != "" {
+			keys[apiKey.Key] = apiKey
+		}
+		if apiKey.Secret != "" {
+			signingKeys[apiKey.Name] = apiKey
+		}
+	}
+	return &AuthMiddleware{
+		ApiKeys:           keys,
+		SigningKeys:       signingKeys,
+		SessionRepository: sessionRepository,
+		TwoFactorPolicy:   twoFactorPolicy,
+		Guard:             guard,
+		HmacVerifier:      hmacVerifier,
+		TrustedProxies:    trustedProxies,
+	}
 }
 
-func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	if "RAHASIA" == request.Header.Get("X-API-Key") {
-		middleware.Handler.ServeHTTP(writer, request)
-	} else {
-		writer.Header().Set("Content-Type", "application/json")
-		writer.WriteHeader(http.StatusUnauthorized)
-		webResponse := web.WebResponse{
-			Code:   http.StatusUnauthorized,
-			Status: "UNAUTHORIZED",
+func (middleware *AuthMiddleware) Authenticate(next httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		authorization := request.Header.Get("Authorization")
+		credential, signed := ParseHmacAuthorization(authorization)
+		bearer := strings.HasPrefix(authorization, "Bearer ")
+
+		key := request.Header.Get("X-API-Key")
+		if signed {
+			key = credential.KeyId
+		} else if bearer {
+			key = strings.TrimPrefix(authorization, "Bearer ")
+		} else if key == "" {
+			key, bearer = subprotocolCredential(request)
+		}
+
+		clientIP := helper.ClientIP(request, middleware.TrustedProxies)
+		ipSubject := middleware.Guard.IpSubject(clientIP)
+		keySubject := middleware.Guard.KeySubject(clientIP, key)
+		middleware.rejectLocked(writer, ipSubject)
+
+		var principal domain.Principal
+		var reason string
+		if signed {
+			principal, reason = middleware.verifySignature(request, credential)
+		} else if bearer {
+			principal, reason = middleware.verifySession(key)
+		} else {
+			principal, reason = middleware.verifyApiKey(key)
 		}
-		helper.WriteToResponseBody(writer, webResponse)
+		if reason != "" {
+			middleware.rejectLocked(writer, keySubject)
+			middleware.Guard.RecordFailure(domain.AuthFailure{
+				ClientIP:  clientIP,
+				KeyPrefix: middleware.Guard.KeyPrefix(key),
+				Method:    request.Method,
+				Path:      request.URL.Path,
+				Reason:    reason,
+			}, []string{ipSubject, keySubject})
+			panic(exception.NewUnauthorizedError(reason))
+		}
+		middleware.Guard.RecordSuccess([]string{keySubject})
+
+		next(writer, request.WithContext(WithPrincipal(request.Context(), principal)), params)
+	}
+}
+
+func (middleware *AuthMiddleware) rejectLocked(writer http.ResponseWriter, subject string) {
+	if lockedFor := middleware.Guard.LockedFor([]string{subject}); lockedFor > 0 {
+		retryAfter := strconv.Itoa(ceilSeconds(lockedFor))
+		writer.Header().Set("Retry-After", retryAfter)
+		panic(exception.NewTooManyRequestsError("too many failed authentication attempts, retry in " + retryAfter + " seconds"))
+	}
+}
+
+func (middleware *AuthMiddleware) verifyApiKey(key string) (domain.Principal, string) {
+	apiKey, ok := middleware.ApiKeys[key]
+	if !ok {
+		return domain.Principal{}, "api key is invalid"
+	}
+	return middleware.apiKeyPrincipal(apiKey), ""
+}
+
+func (middleware *AuthMiddleware) verifySignature(request *http.Request, credential HmacCredential) (domain.Principal, string) {
+	apiKey, ok := middleware.SigningKeys[credential.KeyId]
+	if !ok {
+		return domain.Principal{}, "signing credential is invalid"
 	}
+	err := middleware.HmacVerifier.Verify(request, credential, apiKey.Secret)
+	if err != nil {
+		return domain.Principal{}, err.Error()
+	}
+	return middleware.apiKeyPrincipal(apiKey), ""
+}
+
+func (middleware *AuthMiddleware) apiKeyPrincipal(apiKey domain.ApiKey) domain.Principal {
+	role := apiKey.Role
+	if middleware.TwoFactorPolicy.Requires(role) && !apiKey.TwoFactorExempt {
+		role = domain.RoleUser
+	}
+	return domain.Principal{Name: apiKey.Name, Role: role}
+}
+
+func (middleware *AuthMiddleware) verifySession(token string) (domain.Principal, string) {
+	session, err := middleware.SessionRepository.FindByToken(token)
+	if err != nil {
+		return domain.Principal{}, err.Error()
+	}
+
+	role := session.Role
+	if middleware.TwoFactorPolicy.Requires(role) && !session.TwoFactorVerified {
+		role = domain.RoleUser
+	}
+	return domain.Principal{Name: session.Username, Role: role, UserId: session.UserId}, ""
+}
+
+func subprotocolCredential(request *http.Request) (string, bool) {
+	for _, protocol := range websocket.Subprotocols(request) {
+		if strings.HasPrefix(protocol, BearerSubprotocolPrefix) {
+			return strings.TrimPrefix(protocol, BearerSubprotocolPrefix), true
+		}
+		if strings.HasPrefix(protocol, ApiKeySubprotocolPrefix) {
+			return strings.TrimPrefix(protocol, ApiKeySubprotocolPrefix), false
+		}
+	}
+	return "", false
+}
+
+func (middleware *AuthMiddleware) RequireRole(roles ...string) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			principal, ok := PrincipalFromContext(request.Context())
+			if !ok {
+				panic(exception.NewUnauthorizedError("authentication is required"))
+			}
+			for _, role := range roles {
+				if principal.Role == role {
+					next(writer, request, params)
+					return
+				}
+			}
+			panic(exception.NewForbiddenError("role " + principal.Role + " is not allowed"))
+		}
+	}
+}
+
+func WithPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	helper.RequestInfoFromContext(ctx).Principal = principal.Name
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(domain.Principal)
+	return principal, ok
 }
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+type BodyLimitMiddleware struct {
+	Handler      http.Handler
+	MaxBodyBytes int64
+}
+
+func NewBodyLimitMiddleware(handler http.Handler, maxBodyBytes int64) *BodyLimitMiddleware {
+	return &BodyLimitMiddleware{
+		Handler:      handler,
+		MaxBodyBytes: maxBodyBytes,
+	}
+}
+
+func (middleware *BodyLimitMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Body != nil && middleware.MaxBodyBytes > 0 {
+		request.Body = http.MaxBytesReader(writer, request.Body, middleware.MaxBodyBytes)
+	}
+	middleware.Handler.ServeHTTP(writer, request)
+}
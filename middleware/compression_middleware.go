@@ -0,0 +1,251 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type CompressionConfig struct {
+	MinSize int
+	Level   int
+}
+
+type CompressionMiddleware struct {
+	Handler http.Handler
+	Config  CompressionConfig
+}
+
+func NewCompressionMiddleware(handler http.Handler, config CompressionConfig) *CompressionMiddleware {
+	return &CompressionMiddleware{
+		Handler: handler,
+		Config:  config,
+	}
+}
+
+func (middleware *CompressionMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if !decompressRequestBody(writer, request) {
+		return
+	}
+
+	writer.Header().Add("Vary", "Accept-Encoding")
+	encoding := NegotiateEncoding(request.Header.Get("Accept-Encoding"))
+	if encoding == "" || request.Method == http.MethodHead {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	compressWriter := &compressResponseWriter{
+		ResponseWriter: writer,
+		encoding:       encoding,
+		config:         middleware.Config,
+	}
+	defer compressWriter.Close()
+	middleware.Handler.ServeHTTP(compressWriter, request)
+}
+
+func decompressRequestBody(writer http.ResponseWriter, request *http.Request) bool {
+	var err error
+	var reader io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(request.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return true
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(request.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(request.Body)
+	case "zstd":
+		var decoder *zstd.Decoder
+		decoder, err = zstd.NewReader(request.Body, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(8<<20))
+		if err == nil {
+			reader = decoder.IOReadCloser()
+		}
+	default:
+		writeCompressionError(writer, http.StatusUnsupportedMediaType, "content encoding "+request.Header.Get("Content-Encoding")+" is not supported")
+		return false
+	}
+	if err != nil {
+		writeCompressionError(writer, http.StatusBadRequest, "request body is not validly encoded: "+err.Error())
+		return false
+	}
+
+	request.Body = reader
+	request.Header.Del("Content-Encoding")
+	request.Header.Del("Content-Length")
+	request.ContentLength = -1
+	return true
+}
+
+func NegotiateEncoding(acceptEncoding string) string {
+	best, bestQuality := "", 0.0
+	wildcard := -1.0
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if coding == "*" {
+			wildcard = quality
+			continue
+		}
+		qualities[coding] = quality
+	}
+
+	for _, coding := range []string{"gzip", "deflate", "zstd"} {
+		quality, ok := qualities[coding]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+	return best
+}
+
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding   string
+	config     CompressionConfig
+	status     int
+	buffer     []byte
+	decided    bool
+	compressor io.WriteCloser
+}
+
+func (writer *compressResponseWriter) WriteHeader(status int) {
+	if writer.status == 0 {
+		writer.status = status
+	}
+}
+
+func (writer *compressResponseWriter) Write(bytes []byte) (int, error) {
+	if writer.status == 0 {
+		writer.status = http.StatusOK
+	}
+	if !writer.decided {
+		writer.buffer = append(writer.buffer, bytes...)
+		if len(writer.buffer) >= writer.config.MinSize {
+			if err := writer.decide(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(bytes), nil
+	}
+	if writer.compressor != nil {
+		return writer.compressor.Write(bytes)
+	}
+	return writer.ResponseWriter.Write(bytes)
+}
+
+func (writer *compressResponseWriter) decide(large bool) error {
+	writer.decided = true
+	header := writer.Header()
+	if writer.status == 0 {
+		writer.status = http.StatusOK
+	}
+
+	compress := large &&
+		writer.status != http.StatusNoContent &&
+		writer.status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+	if compress {
+		header.Set("Content-Encoding", writer.encoding)
+		header.Del("Content-Length")
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		var err error
+		switch writer.encoding {
+		case "gzip":
+			writer.compressor, err = gzip.NewWriterLevel(writer.ResponseWriter, writer.config.Level)
+		case "deflate":
+			writer.compressor, err = zlib.NewWriterLevel(writer.ResponseWriter, writer.config.Level)
+		case "zstd":
+			writer.compressor, err = zstd.NewWriter(writer.ResponseWriter, zstd.WithEncoderConcurrency(1))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.ResponseWriter.WriteHeader(writer.status)
+	buffered := writer.buffer
+	writer.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if writer.compressor != nil {
+		_, err := writer.compressor.Write(buffered)
+		return err
+	}
+	_, err := writer.ResponseWriter.Write(buffered)
+	return err
+}
+
+func (writer *compressResponseWriter) Flush() {
+	if !writer.decided {
+		writer.decide(true)
+	}
+	if flusher, ok := writer.compressor.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *compressResponseWriter) Close() error {
+	if !writer.decided {
+		if err := writer.decide(len(writer.buffer) >= writer.config.MinSize); err != nil {
+			return err
+		}
+	}
+	if writer.compressor != nil {
+		return writer.compressor.Close()
+	}
+	return nil
+}
+
+func (writer *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	writer.decided = true
+	return helper.Hijack(writer.ResponseWriter)
+}
+
+func (writer *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
+
+func writeCompressionError(writer http.ResponseWriter, status int, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	webResponse := web.WebResponse{
+		Code:   status,
+		Status: strings.ToUpper(http.StatusText(status)),
+		Data:   message,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
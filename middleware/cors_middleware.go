@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CorsConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+type CorsMiddleware struct {
+	Handler http.Handler
+	Config  CorsConfig
+}
+
+func NewCorsMiddleware(handler http.Handler, config CorsConfig) *CorsMiddleware {
+	return &CorsMiddleware{
+		Handler: handler,
+		Config:  config,
+	}
+}
+
+func (middleware *CorsMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	header := writer.Header()
+	header.Add("Vary", "Origin")
+	preflight := request.Method == http.MethodOptions && request.Header.Get("Access-Control-Request-Method") != ""
+	if preflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if !middleware.Config.AllowsOrigin(origin) {
+		if preflight {
+			corsForbidden(writer, "origin "+origin+" is not allowed")
+			return
+		}
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	header.Set("Access-Control-Allow-Origin", origin)
+	if middleware.Config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if len(middleware.Config.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(middleware.Config.ExposedHeaders, ", "))
+		}
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	method := request.Header.Get("Access-Control-Request-Method")
+	if !containsFold(middleware.Config.AllowedMethods, method) {
+		corsForbidden(writer, "method "+method+" is not allowed")
+		return
+	}
+	for _, requested := range strings.Split(request.Header.Get("Access-Control-Request-Headers"), ",") {
+		requested = strings.TrimSpace(requested)
+		if requested != "" && !containsFold(middleware.Config.AllowedHeaders, requested) {
+			corsForbidden(writer, "header "+requested+" is not allowed")
+			return
+		}
+	}
+
+	header.Set("Access-Control-Allow-Methods", strings.Join(middleware.Config.AllowedMethods, ", "))
+	header.Set("Access-Control-Allow-Headers", strings.Join(middleware.Config.AllowedHeaders, ", "))
+	if middleware.Config.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(middleware.Config.MaxAge.Seconds())))
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// AllowsOrigin never honours a bare "*" when credentials are allowed, so a
+// wildcard config can not hand every site a credentialed response.
+func (config CorsConfig) AllowsOrigin(origin string) bool {
+	for _, pattern := range config.AllowedOrigins {
+		if pattern == "*" {
+			if config.AllowCredentials {
+				continue
+			}
+			return true
+		}
+		if strings.EqualFold(pattern, origin) {
+			return true
+		}
+		if strings.Contains(pattern, "*") {
+			if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(origin)); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func corsForbidden(writer http.ResponseWriter, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusForbidden)
+	webResponse := web.WebResponse{
+		Code:   http.StatusForbidden,
+		Status: "FORBIDDEN",
+		Data:   message,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
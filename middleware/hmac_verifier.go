@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"io"
+	"net/http"
+	"project-restful-api/helper"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const HmacScheme = "HMAC-SHA256"
+
+type HmacConfig struct {
+	ClockSkew time.Duration
+}
+
+type HmacCredential struct {
+	KeyId     string
+	Signature string
+}
+
+type HmacVerifier struct {
+	Config HmacConfig
+	Nonces *NonceCache
+	Now    func() time.Time
+}
+
+func NewHmacVerifier(config HmacConfig) *HmacVerifier {
+	return &HmacVerifier{
+		Config: config,
+		Nonces: NewNonceCache(),
+		Now:    time.Now,
+	}
+}
+
+func ParseHmacAuthorization(header string) (HmacCredential, bool) {
+	if !strings.HasPrefix(header, HmacScheme+" ") {
+		return HmacCredential{}, false
+	}
+
+	credential := HmacCredential{}
+	for _, part := range strings.Split(strings.TrimPrefix(header, HmacScheme+" "), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch name {
+		case "Credential":
+			credential.KeyId = value
+		case "Signature":
+			credential.Signature = value
+		}
+	}
+	return credential, true
+}
+
+func (verifier *HmacVerifier) Verify(request *http.Request, credential HmacCredential, secret string) error {
+	if credential.KeyId == "" || credential.Signature == "" {
+		return errors.New("hmac authorization must contain Credential and Signature")
+	}
+
+	timestamp := request.Header.Get("X-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("X-Timestamp header must be a unix timestamp")
+	}
+
+	now := verifier.Now()
+	signedAt := time.Unix(unix, 0)
+	if signedAt.Before(now.Add(-verifier.Config.ClockSkew)) || signedAt.After(now.Add(verifier.Config.ClockSkew)) {
+		return errors.New("request timestamp is outside the allowed clock skew")
+	}
+
+	nonce := request.Header.Get("X-Nonce")
+	if nonce == "" {
+		return errors.New("X-Nonce header is required")
+	}
+
+	var body []byte
+	if request.Body != nil {
+		body, err = io.ReadAll(request.Body)
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			panic(helper.NewRequestBodyTooLargeError(maxBytesError.Limit))
+		}
+		if err != nil {
+			return errors.New("request body can not be read")
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	canonicalRequest := helper.CanonicalRequest(request.Method, helper.OriginalPath(request), request.URL.Query(), body, timestamp, nonce)
+	expected := helper.HmacSHA256Hex(secret, []byte(canonicalRequest))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(credential.Signature))) {
+		return errors.New("request signature does not match")
+	}
+
+	if !verifier.Nonces.Use(credential.KeyId+":"+nonce, now, signedAt.Add(verifier.Config.ClockSkew)) {
+		return errors.New("request nonce has already been used")
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func JsonApiResource(resourceType string, relations helper.Relations) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			codec, ok := helper.NegotiatedCodec(writer)
+			if !ok || codec.Format() != "jsonapi" {
+				next(writer, request, params)
+				return
+			}
+			page := helper.ParseJsonApiPage(request)
+			next(helper.NewJsonApiResponseWriter(writer, request, resourceType, relations, page), request, params)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/metrics"
+	"strconv"
+	"time"
+)
+
+type MetricsMiddleware struct {
+	Handler http.Handler
+}
+
+func NewMetricsMiddleware(handler http.Handler) *MetricsMiddleware {
+	return &MetricsMiddleware{Handler: handler}
+}
+
+func (middleware *MetricsMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	start := time.Now()
+	recorder := NewResponseRecorder(writer)
+	middleware.Handler.ServeHTTP(recorder, request)
+
+	route := helper.RequestInfoFromContext(request.Context()).Route
+	if route == "" {
+		route = "unmatched"
+	}
+	status := recorder.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	method := request.Method
+	if route == "unmatched" {
+		method = "OTHER"
+	}
+	metrics.HttpRequestsTotal.Inc(method, route, strconv.Itoa(status))
+	metrics.HttpRequestDuration.Observe(time.Since(start).Seconds(), method, route, strconv.Itoa(status))
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"project-restful-api/event"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"strings"
+)
+
+type NegotiationMiddleware struct {
+	Handler  http.Handler
+	Registry *helper.CodecRegistry
+}
+
+func NewNegotiationMiddleware(handler http.Handler, registry *helper.CodecRegistry) *NegotiationMiddleware {
+	return &NegotiationMiddleware{
+		Handler:  handler,
+		Registry: registry,
+	}
+}
+
+func (middleware *NegotiationMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Add("Vary", "Accept")
+
+	var codec helper.Codec
+	var ok bool
+	if format := request.URL.Query().Get("format"); format != "" {
+		codec, ok = middleware.Registry.ForFormat(format)
+	} else {
+		codec, ok = middleware.Registry.Negotiate(request.Header.Get("Accept"))
+		if !ok && acceptsEventStream(request.Header.Get("Accept")) {
+			codec, ok = middleware.Registry.Default(), true
+		}
+	}
+	if !ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusNotAcceptable)
+		webResponse := web.WebResponse{
+			Code:   http.StatusNotAcceptable,
+			Status: "NOT ACCEPTABLE",
+			Data:   "response can only be produced as one of " + strings.Join(middleware.Registry.MediaTypes(), ", "),
+		}
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
+
+	negotiated := helper.NewNegotiatedResponseWriter(writer, codec)
+	defer negotiated.Close()
+	middleware.Handler.ServeHTTP(negotiated, request)
+}
+
+func acceptsEventStream(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == event.StreamContentType {
+			return true
+		}
+	}
+	return false
+}
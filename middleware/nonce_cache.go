@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+type NonceCache struct {
+	mutex   sync.Mutex
+	nonces  map[string]time.Time
+	sweptAt time.Time
+}
+
+func NewNonceCache() *NonceCache {
+	return &NonceCache{nonces: make(map[string]time.Time)}
+}
+
+func (cache *NonceCache) Use(nonce string, now time.Time, expiresAt time.Time) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if now.Sub(cache.sweptAt) > time.Minute {
+		cache.sweptAt = now
+		for key, expiry := range cache.nonces {
+			if now.After(expiry) {
+				delete(cache.nonces, key)
+			}
+		}
+	}
+
+	if expiry, ok := cache.nonces[nonce]; ok && !now.After(expiry) {
+		return false
+	}
+	cache.nonces[nonce] = expiresAt
+	return true
+}
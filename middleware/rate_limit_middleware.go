@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RateLimitMiddleware struct {
+	Store          RateLimitStore
+	TrustedProxies helper.TrustedProxies
+}
+
+func NewRateLimitMiddleware(store RateLimitStore, trustedProxies helper.TrustedProxies) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		Store:          store,
+		TrustedProxies: trustedProxies,
+	}
+}
+
+func (middleware *RateLimitMiddleware) Limit(group string, config RateLimitConfig) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			key := group + ":" + middleware.clientKey(request)
+			result := middleware.Store.Take(request.Context(), key, config)
+
+			writer.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			writer.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			writer.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				retryAfter := strconv.Itoa(ceilSeconds(result.RetryAfter))
+				writer.Header().Set("Retry-After", retryAfter)
+				panic(exception.NewTooManyRequestsError("rate limit exceeded, retry in " + retryAfter + " seconds"))
+			}
+			next(writer, request, params)
+		}
+	}
+}
+
+func (middleware *RateLimitMiddleware) clientKey(request *http.Request) string {
+	if principal, ok := PrincipalFromContext(request.Context()); ok {
+		return "principal:" + principal.Name
+	}
+	return "ip:" + helper.ClientIP(request, middleware.TrustedProxies)
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type RateLimitConfig struct {
+	Limit  int
+	Period time.Duration
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, config RateLimitConfig) RateLimitResult
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	period    time.Duration
+}
+
+type MemoryRateLimitStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+	Now     func() time.Time
+}
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		Now:     time.Now,
+	}
+}
+
+func (store *MemoryRateLimitStore) Take(ctx context.Context, key string, config RateLimitConfig) RateLimitResult {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.Now()
+	store.sweep(now)
+
+	capacity := float64(config.Limit)
+	refillPerSecond := capacity / config.Period.Seconds()
+
+	bucket, ok := store.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		store.buckets[key] = bucket
+	}
+	bucket.period = config.Period
+	bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*refillPerSecond)
+	bucket.updatedAt = now
+
+	result := RateLimitResult{Limit: config.Limit}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / refillPerSecond)
+	}
+	result.Remaining = int(math.Floor(bucket.tokens))
+	result.Reset = secondsToDuration((capacity - bucket.tokens) / refillPerSecond)
+	return result
+}
+
+func (store *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(store.sweptAt) < time.Minute {
+		return
+	}
+	store.sweptAt = now
+	for key, bucket := range store.buckets {
+		if now.Sub(bucket.updatedAt) > bucket.period {
+			delete(store.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
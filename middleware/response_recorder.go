@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"project-restful-api/helper"
+)
+
+type ResponseRecorder struct {
+	http.ResponseWriter
+	Status int
+	Bytes  int
+}
+
+func NewResponseRecorder(writer http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: writer}
+}
+
+func (recorder *ResponseRecorder) WriteHeader(status int) {
+	if recorder.Status == 0 {
+		recorder.Status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *ResponseRecorder) Write(bytes []byte) (int, error) {
+	if recorder.Status == 0 {
+		recorder.Status = http.StatusOK
+	}
+	written, err := recorder.ResponseWriter.Write(bytes)
+	recorder.Bytes += written
+	return written, err
+}
+
+func (recorder *ResponseRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buffered, err := helper.Hijack(recorder.ResponseWriter)
+	if err == nil && recorder.Status == 0 {
+		recorder.Status = http.StatusSwitchingProtocols
+	}
+	return conn, buffered, err
+}
+
+func (recorder *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
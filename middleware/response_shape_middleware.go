@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func ResponseShape(resource interface{}, relations helper.Relations) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			shape := helper.ParseResponseShape(request, resource, relations)
+			if shape.IsEmpty() {
+				next(writer, request, params)
+				return
+			}
+			next(helper.NewShapedResponseWriter(writer, request.Context(), shape, relations), request, params)
+		}
+	}
+}
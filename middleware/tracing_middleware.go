@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"project-restful-api/helper"
+	"project-restful-api/tracing"
+)
+
+type TracingMiddleware struct {
+	Handler http.Handler
+	Tracer  *tracing.Tracer
+}
+
+func NewTracingMiddleware(handler http.Handler, tracer *tracing.Tracer) *TracingMiddleware {
+	return &TracingMiddleware{
+		Handler: handler,
+		Tracer:  tracer,
+	}
+}
+
+func (middleware *TracingMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	if parent, ok := tracing.ParseTraceparent(request.Header.Get("traceparent")); ok {
+		ctx = tracing.ContextWithSpanContext(ctx, parent)
+	}
+
+	ctx, span := middleware.Tracer.Start(ctx, request.Method, tracing.SpanKindServer)
+	defer span.End()
+
+	info := helper.RequestInfoFromContext(ctx)
+	info.TraceId = span.Context.TraceId.String()
+	writer.Header().Set("traceparent", span.Context.Traceparent())
+
+	recorder := NewResponseRecorder(writer)
+	middleware.Handler.ServeHTTP(recorder, request.WithContext(ctx))
+
+	status := recorder.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if info.Route != "" {
+		span.SetName(request.Method + " " + info.Route)
+		span.SetAttribute("http.route", info.Route)
+	}
+	span.SetAttribute("http.method", request.Method)
+	span.SetAttribute("http.target", request.URL.Path)
+	span.SetAttribute("http.status_code", status)
+	span.SetAttribute("http.request_id", info.RequestId)
+	if status >= http.StatusInternalServerError {
+		span.SetError(http.StatusText(status))
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"sort"
+	"sync"
+)
+
+type TwoFactorPolicy struct {
+	mutex sync.RWMutex
+	roles map[string]bool
+}
+
+func NewTwoFactorPolicy(roles []string) *TwoFactorPolicy {
+	policy := &TwoFactorPolicy{}
+	policy.SetRoles(roles)
+	return policy
+}
+
+func (policy *TwoFactorPolicy) Requires(role string) bool {
+	policy.mutex.RLock()
+	defer policy.mutex.RUnlock()
+	return policy.roles[role]
+}
+
+func (policy *TwoFactorPolicy) Roles() []string {
+	policy.mutex.RLock()
+	defer policy.mutex.RUnlock()
+
+	roles := make([]string, 0, len(policy.roles))
+	for role := range policy.roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func (policy *TwoFactorPolicy) SetRoles(roles []string) {
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+
+	policy.roles = make(map[string]bool, len(roles))
+	for _, role := range roles {
+		policy.roles[role] = true
+	}
+}
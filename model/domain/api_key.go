@@ -0,0 +1,16 @@
+package domain
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type ApiKey struct {
+	Key    string
+	Name   string
+	Role   string
+	Secret string
+	// TwoFactorExempt marks a service key that may keep a role the
+	// two-factor policy would otherwise withhold from it.
+	TwoFactorExempt bool
+}
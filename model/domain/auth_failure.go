@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type AuthFailure struct {
+	Time      time.Time
+	ClientIP  string
+	KeyPrefix string
+	Method    string
+	Path      string
+	Reason    string
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+type BuildInfo struct {
+	Version   string
+	StartedAt time.Time
+}
@@ -0,0 +1,9 @@
+package domain
+
+const (
+	CategoryCreated = "category.created"
+	CategoryUpdated = "category.updated"
+	CategoryDeleted = "category.deleted"
+)
+
+var CategoryEventTypes = []string{CategoryCreated, CategoryUpdated, CategoryDeleted}
@@ -0,0 +1,7 @@
+package domain
+
+type Principal struct {
+	Name   string
+	Role   string
+	UserId int
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type QueryStat struct {
+	Query        string
+	Count        int
+	SlowCount    int
+	TotalTime    time.Duration
+	MaxTime      time.Duration
+	LastExecuted time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type Session struct {
+	Token             string
+	UserId            int
+	Username          string
+	Role              string
+	TwoFactorVerified bool
+	ExpiresAt         time.Time
+}
@@ -0,0 +1,8 @@
+package domain
+
+type Todo struct {
+	Id         int
+	CategoryId int
+	Title      string
+	Done       bool
+}
@@ -0,0 +1,12 @@
+package domain
+
+type User struct {
+	Id            int
+	Username      string
+	PasswordHash  string
+	Role          string
+	TotpSecret    string
+	TotpEnabled   bool
+	RecoveryCodes []string
+	TotpLastStep  int64
+}
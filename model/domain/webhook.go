@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type Webhook struct {
+	Id                  int
+	Url                 string
+	Secret              string
+	EventTypes          []string
+	Active              bool
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+}
+
+type WebhookDelivery struct {
+	Id             int
+	WebhookId      int
+	EventId        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    time.Time
+}
+
+type WebhookAttempt struct {
+	Id          int
+	DeliveryId  int
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+type WebhookJob struct {
+	Delivery WebhookDelivery
+	Url      string
+	Secret   string
+}
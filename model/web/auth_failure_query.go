@@ -0,0 +1,7 @@
+package web
+
+type AuthFailureQuery struct {
+	Limit     int    `validate:"min=1,max=1000" query:"limit"`
+	ClientIP  string `validate:"omitempty,ip" query:"ip"`
+	KeyPrefix string `query:"key_prefix"`
+}
@@ -0,0 +1,12 @@
+package web
+
+import "time"
+
+type AuthFailureResponse struct {
+	Time      time.Time `json:"time"`
+	ClientIP  string    `json:"client_ip"`
+	KeyPrefix string    `json:"key_prefix"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Reason    string    `json:"reason"`
+}
@@ -0,0 +1,5 @@
+package web
+
+type CategoryIdRequest struct {
+	Id int `validate:"required,min=1" json:"id" path:"categoryId"`
+}
@@ -1,6 +1,6 @@
 package web
 
 type CategoryUpdateRequest struct {
-	Id   int    `validate:"required" json:"id"`
+	Id   int    `validate:"required" json:"id" path:"categoryId"`
 	Name string `validate:"required,max=200,min=1" json:"name"`
 }
@@ -0,0 +1,13 @@
+package web
+
+type EnvelopeResponse struct {
+	Data  interface{}    `json:"data,omitempty"`
+	Error *EnvelopeError `json:"error,omitempty"`
+}
+
+type EnvelopeError struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,5 @@
+package web
+
+type ErrorResponse struct {
+	RequestId string `json:"request_id"`
+}
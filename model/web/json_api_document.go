@@ -0,0 +1,38 @@
+package web
+
+type JsonApiDocument struct {
+	Data     interface{}            `json:"data,omitempty"`
+	Errors   []JsonApiError         `json:"errors,omitempty"`
+	Included []JsonApiResource      `json:"included,omitempty"`
+	Links    map[string]string      `json:"links,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	JsonApi  JsonApiVersion         `json:"jsonapi"`
+}
+
+type JsonApiVersion struct {
+	Version string `json:"version"`
+}
+
+type JsonApiResource struct {
+	Type          string                         `json:"type"`
+	Id            string                         `json:"id"`
+	Attributes    interface{}                    `json:"attributes,omitempty"`
+	Relationships map[string]JsonApiRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+type JsonApiRelationship struct {
+	Data interface{} `json:"data"`
+}
+
+type JsonApiResourceIdentifier struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type JsonApiError struct {
+	Status string      `json:"status"`
+	Title  string      `json:"title"`
+	Detail string      `json:"detail,omitempty"`
+	Meta   interface{} `json:"meta,omitempty"`
+}
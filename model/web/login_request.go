@@ -0,0 +1,8 @@
+package web
+
+type LoginRequest struct {
+	Username     string `validate:"required" json:"username"`
+	Password     string `validate:"required" json:"password"`
+	TotpCode     string `json:"totp_code"`
+	RecoveryCode string `json:"recovery_code"`
+}
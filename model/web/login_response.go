@@ -0,0 +1,9 @@
+package web
+
+import "time"
+
+type LoginResponse struct {
+	Token             string    `json:"token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	TwoFactorVerified bool      `json:"two_factor_verified"`
+}
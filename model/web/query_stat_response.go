@@ -0,0 +1,13 @@
+package web
+
+import "time"
+
+type QueryStatResponse struct {
+	Query        string    `json:"query"`
+	Count        int       `json:"count"`
+	SlowCount    int       `json:"slow_count"`
+	TotalMs      float64   `json:"total_ms"`
+	MeanMs       float64   `json:"mean_ms"`
+	MaxMs        float64   `json:"max_ms"`
+	LastExecuted time.Time `json:"last_executed"`
+}
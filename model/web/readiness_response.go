@@ -0,0 +1,6 @@
+package web
+
+type ReadinessResponse struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
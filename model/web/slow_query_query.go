@@ -0,0 +1,5 @@
+package web
+
+type SlowQueryQuery struct {
+	Limit int `validate:"min=1,max=100" query:"limit"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import "time"
+
+type StatusResponse struct {
+	Version   string                `json:"version"`
+	StartedAt time.Time             `json:"started_at"`
+	Uptime    string                `json:"uptime"`
+	Database  DatabaseStatsResponse `json:"database"`
+}
+
+type DatabaseStatsResponse struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
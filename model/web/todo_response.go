@@ -0,0 +1,7 @@
+package web
+
+type TodoResponse struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
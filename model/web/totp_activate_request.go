@@ -0,0 +1,5 @@
+package web
+
+type TotpActivateRequest struct {
+	Code string `validate:"required,len=6,numeric" json:"code"`
+}
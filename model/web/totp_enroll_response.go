@@ -0,0 +1,7 @@
+package web
+
+type TotpEnrollResponse struct {
+	Secret          string   `json:"secret"`
+	ProvisioningUri string   `json:"provisioning_uri"`
+	RecoveryCodes   []string `json:"recovery_codes"`
+}
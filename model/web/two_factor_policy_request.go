@@ -0,0 +1,5 @@
+package web
+
+type TwoFactorPolicyRequest struct {
+	Roles []string `validate:"dive,oneof=user admin" json:"roles"`
+}
@@ -0,0 +1,5 @@
+package web
+
+type TwoFactorPolicyResponse struct {
+	Roles []string `json:"roles"`
+}
@@ -0,0 +1,7 @@
+package web
+
+type UserCreateRequest struct {
+	Username string `validate:"required,max=100,min=3" json:"username"`
+	Password string `validate:"required,max=72,min=8" json:"password"`
+	Role     string `validate:"required,oneof=user admin" json:"role"`
+}
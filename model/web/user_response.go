@@ -0,0 +1,8 @@
+package web
+
+type UserResponse struct {
+	Id          int    `json:"id"`
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+	TotpEnabled bool   `json:"totp_enabled"`
+}
@@ -0,0 +1,7 @@
+package web
+
+type WebhookCreateRequest struct {
+	Url        string   `validate:"required,max=2048" json:"url"`
+	Secret     string   `validate:"required,min=16,max=200" json:"secret"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=category.created category.updated category.deleted" json:"event_types"`
+}
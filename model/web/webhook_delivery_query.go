@@ -0,0 +1,6 @@
+package web
+
+type WebhookDeliveryQuery struct {
+	WebhookId int `validate:"required" path:"webhookId"`
+	Limit     int `validate:"min=1,max=100" query:"limit"`
+}
@@ -0,0 +1,10 @@
+package web
+
+type WebhookIdRequest struct {
+	Id int `validate:"required" json:"id" path:"webhookId"`
+}
+
+type WebhookDeliveryIdRequest struct {
+	WebhookId  int `validate:"required" path:"webhookId"`
+	DeliveryId int `validate:"required" path:"deliveryId"`
+}
@@ -0,0 +1,10 @@
+package web
+
+import "time"
+
+type WebhookPayload struct {
+	Id        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
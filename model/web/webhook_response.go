@@ -0,0 +1,33 @@
+package web
+
+import "time"
+
+type WebhookResponse struct {
+	Id                  int       `json:"id"`
+	Url                 string    `json:"url"`
+	EventTypes          []string  `json:"event_types"`
+	Active              bool      `json:"active"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             int                      `json:"id"`
+	EventId        string                   `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Status         string                   `json:"status"`
+	AttemptCount   int                      `json:"attempt_count"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at,omitempty"`
+	LastStatusCode int                      `json:"last_status_code"`
+	LastError      string                   `json:"last_error"`
+	CreatedAt      time.Time                `json:"created_at"`
+	DeliveredAt    *time.Time               `json:"delivered_at,omitempty"`
+	Attempts       []WebhookAttemptResponse `json:"attempts"`
+}
+
+type WebhookAttemptResponse struct {
+	StatusCode  int       `json:"status_code"`
+	Error       string    `json:"error"`
+	DurationMs  float64   `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
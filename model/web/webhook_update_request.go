@@ -0,0 +1,9 @@
+package web
+
+type WebhookUpdateRequest struct {
+	Id         int      `validate:"required" json:"id" path:"webhookId"`
+	Url        string   `validate:"required,max=2048" json:"url"`
+	Secret     string   `validate:"omitempty,min=16,max=200" json:"secret"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=category.created category.updated category.deleted" json:"event_types"`
+	Active     bool     `json:"active"`
+}
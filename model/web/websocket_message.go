@@ -0,0 +1,23 @@
+package web
+
+import "encoding/json"
+
+type WebSocketRequest struct {
+	Type       string          `json:"type"`
+	Id         string          `json:"id,omitempty"`
+	Categories []int           `json:"categories,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+type WebSocketMessage struct {
+	Type    string      `json:"type"`
+	Id      string      `json:"id,omitempty"`
+	Event   string      `json:"event,omitempty"`
+	EventId uint64      `json:"eventId,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type WebSocketSubscription struct {
+	All        bool  `json:"all"`
+	Categories []int `json:"categories"`
+}
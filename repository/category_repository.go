@@ -12,4 +12,5 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, tx *sql.Tx, category domain.Category)
 	FindById(ctx context.Context, tx *sql.Tx, categoryId int) (domain.Category, error)
 	FindAll(ctx context.Context, tx *sql.Tx) []domain.Category
+	FindParents(ctx context.Context, tx *sql.Tx, categoryIds []int) map[int]domain.Category
 }
@@ -5,18 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"project-restful-api/model/domain"
+	"strings"
 )
 
 type CategoryRepositoryImpl struct {
+	QueryLog *QueryLog
 }
 
-func NewCategoryRepository() CategoryRepository {
-	return &CategoryRepositoryImpl{}
+func NewCategoryRepository(queryLog *QueryLog) CategoryRepository {
+	return &CategoryRepositoryImpl{
+		QueryLog: queryLog,
+	}
 }
 
 func (repository *CategoryRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, category domain.Category) domain.Category {
 	SQL := "insert into category(name) values(?)"
-	result, err := tx.ExecContext(ctx, SQL, category.Name)
+	result, err := repository.QueryLog.Wrap(tx, "CategoryRepository.Create").ExecContext(ctx, SQL, category.Name)
 	if err != nil {
 		panic(err)
 	}
@@ -30,7 +34,7 @@ func (repository *CategoryRepositoryImpl) Create(ctx context.Context, tx *sql.Tx
 
 func (repository *CategoryRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, category domain.Category) domain.Category {
 	SQL := "update category set name = ? where id = ?"
-	_, err := tx.ExecContext(ctx, SQL, category.Name, category.Id)
+	_, err := repository.QueryLog.Wrap(tx, "CategoryRepository.Update").ExecContext(ctx, SQL, category.Name, category.Id)
 	if err != nil {
 		panic(err)
 	}
@@ -39,7 +43,7 @@ func (repository *CategoryRepositoryImpl) Update(ctx context.Context, tx *sql.Tx
 
 func (repository *CategoryRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, category domain.Category) {
 	SQL := "delete from category where id = ?"
-	_, err := tx.ExecContext(ctx, SQL, category.Id)
+	_, err := repository.QueryLog.Wrap(tx, "CategoryRepository.Delete").ExecContext(ctx, SQL, category.Id)
 	if err != nil {
 		panic(err)
 	}
@@ -47,7 +51,7 @@ func (repository *CategoryRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx
 
 func (repository *CategoryRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, categoryId int) (domain.Category, error) {
 	SQL := "select id, name from category where id = ?"
-	rows, err := tx.QueryContext(ctx, SQL, categoryId)
+	rows, err := repository.QueryLog.Wrap(tx, "CategoryRepository.FindById").QueryContext(ctx, SQL, categoryId)
 	if err != nil {
 		panic(err)
 	}
@@ -68,7 +72,7 @@ func (repository *CategoryRepositoryImpl) FindById(ctx context.Context, tx *sql.
 
 func (repository *CategoryRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Category {
 	SQl := "select id, name from category "
-	rows, err := tx.QueryContext(ctx, SQl)
+	rows, err := repository.QueryLog.Wrap(tx, "CategoryRepository.FindAll").QueryContext(ctx, SQl)
 	if err != nil {
 		panic(err)
 	}
@@ -87,3 +91,32 @@ func (repository *CategoryRepositoryImpl) FindAll(ctx context.Context, tx *sql.T
 	}
 	return categories
 }
+
+func (repository *CategoryRepositoryImpl) FindParents(ctx context.Context, tx *sql.Tx, categoryIds []int) map[int]domain.Category {
+	parents := map[int]domain.Category{}
+	if len(categoryIds) == 0 {
+		return parents
+	}
+
+	args := make([]interface{}, len(categoryIds))
+	for i, categoryId := range categoryIds {
+		args[i] = categoryId
+	}
+	SQL := "select child.id, parent.id, parent.name from category child join category parent on parent.id = child.parent_id where child.id in (?" + strings.Repeat(", ?", len(categoryIds)-1) + ")"
+	rows, err := repository.QueryLog.Wrap(tx, "CategoryRepository.FindParents").QueryContext(ctx, SQL, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var childId int
+		parent := domain.Category{}
+		err := rows.Scan(&childId, &parent.Id, &parent.Name)
+		if err != nil {
+			panic(err)
+		}
+		parents[childId] = parent
+	}
+	return parents
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/helper"
+	"strconv"
+)
+
+type Migration struct {
+	Version int
+	SQL     string
+}
+
+var Migrations = []Migration{
+	{
+		Version: 1,
+		SQL: `create table if not exists category (
+			id int primary key auto_increment,
+			name varchar(200) not null
+		) engine = InnoDB`,
+	},
+	{
+		Version: 2,
+		SQL: `create table if not exists user (
+			id int primary key auto_increment,
+			username varchar(100) not null unique,
+			password_hash varchar(100) not null,
+			role varchar(20) not null,
+			totp_secret varchar(64) not null default '',
+			totp_enabled boolean not null default false,
+			recovery_codes text not null
+		) engine = InnoDB`,
+	},
+	{
+		Version: 3,
+		SQL: `create table if not exists webhook (
+			id int primary key auto_increment,
+			url varchar(2048) not null,
+			secret varchar(200) not null,
+			event_types varchar(200) not null,
+			active boolean not null default true,
+			consecutive_failures int not null default 0,
+			created_at datetime(3) not null
+		) engine = InnoDB`,
+	},
+	{
+		Version: 4,
+		SQL: `create table if not exists webhook_delivery (
+			id int primary key auto_increment,
+			webhook_id int not null,
+			event_id varchar(64) not null,
+			event_type varchar(100) not null,
+			payload mediumtext not null,
+			status varchar(20) not null,
+			attempts int not null default 0,
+			next_attempt_at datetime(3) not null,
+			last_status_code int not null default 0,
+			last_error varchar(1000) not null default '',
+			created_at datetime(3) not null,
+			delivered_at datetime(3) null,
+			index webhook_delivery_due (status, next_attempt_at),
+			index webhook_delivery_history (webhook_id, id),
+			foreign key (webhook_id) references webhook (id) on delete cascade
+		) engine = InnoDB`,
+	},
+	{
+		Version: 5,
+		SQL: `create table if not exists webhook_attempt (
+			id int primary key auto_increment,
+			delivery_id int not null,
+			status_code int not null,
+			error varchar(1000) not null,
+			duration_ms int not null,
+			attempted_at datetime(3) not null,
+			index webhook_attempt_delivery (delivery_id),
+			foreign key (delivery_id) references webhook_delivery (id) on delete cascade
+		) engine = InnoDB`,
+	},
+	{
+		Version: 6,
+		SQL:     `alter table user add column totp_last_step bigint not null default 0`,
+	},
+	{
+		Version: 7,
+		SQL: `alter table category
+			add column parent_id int null,
+			add foreign key (parent_id) references category (id) on delete set null`,
+	},
+	{
+		Version: 8,
+		SQL: `create table if not exists todo (
+			id int primary key auto_increment,
+			category_id int not null,
+			title varchar(200) not null,
+			done boolean not null default false,
+			index todo_category (category_id, id),
+			foreign key (category_id) references category (id) on delete cascade
+		) engine = InnoDB`,
+	},
+}
+
+func Migrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "create table if not exists schema_migrations (version int primary key, applied_at timestamp not null default current_timestamp) engine = InnoDB")
+	if err != nil {
+		return err
+	}
+
+	current, err := currentMigrationVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range Migrations {
+		if migration.Version <= current {
+			continue
+		}
+		_, err := db.ExecContext(ctx, migration.SQL)
+		if err != nil {
+			return err
+		}
+		_, err = db.ExecContext(ctx, "insert into schema_migrations(version) values(?)", migration.Version)
+		if err != nil {
+			return err
+		}
+		helper.LogInfo("applied migration " + strconv.Itoa(migration.Version))
+	}
+	return nil
+}
+
+func PendingMigrations(ctx context.Context, db *sql.DB) (int, error) {
+	current, err := currentMigrationVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, migration := range Migrations {
+		if migration.Version > current {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+func currentMigrationVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, "select coalesce(max(version), 0) from schema_migrations").Scan(&version)
+	return version, err
+}
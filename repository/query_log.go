@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"project-restful-api/helper"
+	"project-restful-api/model/domain"
+	"project-restful-api/tracing"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type QueryLogConfig struct {
+	SlowThreshold  time.Duration
+	LogStatements  bool
+	MaxFingerprint int
+}
+
+type QueryLog struct {
+	Config QueryLogConfig
+	mutex  sync.Mutex
+	stats  map[string]*domain.QueryStat
+}
+
+func NewQueryLog(config QueryLogConfig) *QueryLog {
+	return &QueryLog{
+		Config: config,
+		stats:  map[string]*domain.QueryStat{},
+	}
+}
+
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type InstrumentedQuerier struct {
+	Querier  Querier
+	QueryLog *QueryLog
+	Name     string
+}
+
+func (queryLog *QueryLog) Wrap(querier Querier, name string) *InstrumentedQuerier {
+	return &InstrumentedQuerier{
+		Querier:  querier,
+		QueryLog: queryLog,
+		Name:     name,
+	}
+}
+
+func (querier *InstrumentedQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startStatementSpan(ctx, querier.Name, query)
+	defer span.End()
+
+	start := time.Now()
+	result, err := querier.Querier.ExecContext(ctx, query, args...)
+	duration := time.Since(start)
+
+	rowsAffected := int64(-1)
+	if err == nil {
+		rowsAffected, _ = result.RowsAffected()
+		span.SetAttribute("db.rows_affected", rowsAffected)
+	} else {
+		span.SetError(err)
+	}
+	querier.QueryLog.Record(ctx, query, args, duration, rowsAffected, err)
+	return result, err
+}
+
+func (querier *InstrumentedQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, querier.Name, query)
+	defer span.End()
+
+	start := time.Now()
+	rows, err := querier.Querier.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.SetError(err)
+	}
+	querier.QueryLog.Record(ctx, query, args, time.Since(start), -1, err)
+	return rows, err
+}
+
+func (queryLog *QueryLog) Record(ctx context.Context, query string, args []interface{}, duration time.Duration, rowsAffected int64, err error) {
+	fingerprint := NormalizeQuery(query)
+	slow := queryLog.Config.SlowThreshold > 0 && duration >= queryLog.Config.SlowThreshold
+
+	queryLog.mutex.Lock()
+	stat, ok := queryLog.stats[fingerprint]
+	if !ok && len(queryLog.stats) < queryLog.Config.MaxFingerprint {
+		stat = &domain.QueryStat{Query: fingerprint}
+		queryLog.stats[fingerprint] = stat
+	}
+	if stat != nil {
+		stat.Count++
+		stat.TotalTime += duration
+		stat.LastExecuted = time.Now()
+		if duration > stat.MaxTime {
+			stat.MaxTime = duration
+		}
+		if slow {
+			stat.SlowCount++
+		}
+	}
+	queryLog.mutex.Unlock()
+
+	if !queryLog.Config.LogStatements && !slow && err == nil {
+		return
+	}
+
+	entry := map[string]interface{}{
+		"level":       "debug",
+		"message":     "sql statement executed",
+		"request_id":  helper.RequestId(ctx),
+		"statement":   fingerprint,
+		"args":        redactArgs(args),
+		"duration_ms": float64(duration.Microseconds()) / 1000,
+		"slow":        slow,
+	}
+	if rowsAffected >= 0 {
+		entry["rows_affected"] = rowsAffected
+	}
+	if slow {
+		entry["level"] = "warn"
+		entry["message"] = "slow sql statement"
+	}
+	if err != nil {
+		entry["level"] = "error"
+		entry["error"] = err.Error()
+	}
+	helper.LogJSON(entry)
+}
+
+func (queryLog *QueryLog) Slowest(limit int) []domain.QueryStat {
+	queryLog.mutex.Lock()
+	stats := make([]domain.QueryStat, 0, len(queryLog.stats))
+	for _, stat := range queryLog.stats {
+		stats = append(stats, *stat)
+	}
+	queryLog.mutex.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].MaxTime != stats[j].MaxTime {
+			return stats[i].MaxTime > stats[j].MaxTime
+		}
+		return stats[i].Query < stats[j].Query
+	})
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats
+}
+
+var (
+	stringLiteralPattern   = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*"`)
+	numberLiteralPattern   = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	placeholderListPattern = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+	whitespacePattern      = regexp.MustCompile(`\s+`)
+)
+
+func NormalizeQuery(query string) string {
+	query = stringLiteralPattern.ReplaceAllString(query, "?")
+	query = numberLiteralPattern.ReplaceAllString(query, "?")
+	query = placeholderListPattern.ReplaceAllString(query, "(?+)")
+	query = whitespacePattern.ReplaceAllString(query, " ")
+	return strings.ToLower(strings.TrimSpace(query))
+}
+
+func redactArgs(args []interface{}) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("[redacted %T]", arg)
+	}
+	return redacted
+}
+
+func startStatementSpan(ctx context.Context, name string, statement string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.FromContext(ctx).Start(ctx, name, tracing.SpanKindClient)
+	span.SetAttribute("db.system", "mysql")
+	span.SetAttribute("db.statement", statement)
+	return ctx, span
+}
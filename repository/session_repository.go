@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"project-restful-api/model/domain"
+)
+
+type SessionRepository interface {
+	Save(session domain.Session) domain.Session
+	FindByToken(token string) (domain.Session, error)
+	Delete(token string)
+}
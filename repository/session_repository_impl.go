@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+	"project-restful-api/model/domain"
+	"sync"
+	"time"
+)
+
+type SessionRepositoryImpl struct {
+	mutex    sync.RWMutex
+	sessions map[string]domain.Session
+}
+
+func NewSessionRepository() SessionRepository {
+	return &SessionRepositoryImpl{
+		sessions: make(map[string]domain.Session),
+	}
+}
+
+func (repository *SessionRepositoryImpl) Save(session domain.Session) domain.Session {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	now := time.Now()
+	for token, existing := range repository.sessions {
+		if now.After(existing.ExpiresAt) {
+			delete(repository.sessions, token)
+		}
+	}
+	repository.sessions[session.Token] = session
+	return session
+}
+
+func (repository *SessionRepositoryImpl) FindByToken(token string) (domain.Session, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	session, ok := repository.sessions[token]
+	if !ok || time.Now().After(session.ExpiresAt) {
+		return domain.Session{}, errors.New("session is invalid or expired")
+	}
+	return session, nil
+}
+
+func (repository *SessionRepositoryImpl) Delete(token string) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	delete(repository.sessions, token)
+}
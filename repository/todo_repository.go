@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+)
+
+type TodoRepository interface {
+	FindByCategoryIds(ctx context.Context, tx *sql.Tx, categoryIds []int) map[int][]domain.Todo
+}
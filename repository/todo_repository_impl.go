@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+	"strings"
+)
+
+type TodoRepositoryImpl struct {
+	QueryLog *QueryLog
+}
+
+func NewTodoRepository(queryLog *QueryLog) TodoRepository {
+	return &TodoRepositoryImpl{
+		QueryLog: queryLog,
+	}
+}
+
+func (repository *TodoRepositoryImpl) FindByCategoryIds(ctx context.Context, tx *sql.Tx, categoryIds []int) map[int][]domain.Todo {
+	todos := map[int][]domain.Todo{}
+	if len(categoryIds) == 0 {
+		return todos
+	}
+
+	args := make([]interface{}, len(categoryIds))
+	for i, categoryId := range categoryIds {
+		args[i] = categoryId
+	}
+	SQL := "select id, category_id, title, done from todo where category_id in (?" + strings.Repeat(", ?", len(categoryIds)-1) + ") order by id"
+	rows, err := repository.QueryLog.Wrap(tx, "TodoRepository.FindByCategoryIds").QueryContext(ctx, SQL, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		todo := domain.Todo{}
+		err := rows.Scan(&todo.Id, &todo.CategoryId, &todo.Title, &todo.Done)
+		if err != nil {
+			panic(err)
+		}
+		todos[todo.CategoryId] = append(todos[todo.CategoryId], todo)
+	}
+	return todos
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, user domain.User) domain.User
+	Update(ctx context.Context, tx *sql.Tx, user domain.User) domain.User
+	FindById(ctx context.Context, tx *sql.Tx, userId int) (domain.User, error)
+	FindByUsername(ctx context.Context, tx *sql.Tx, username string) (domain.User, error)
+	UseTotpStep(ctx context.Context, tx *sql.Tx, userId int, step int64) bool
+}
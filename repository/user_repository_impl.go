@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"project-restful-api/model/domain"
+	"strings"
+)
+
+type UserRepositoryImpl struct {
+}
+
+func NewUserRepository() UserRepository {
+	return &UserRepositoryImpl{}
+}
+
+func (repository *UserRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, user domain.User) domain.User {
+	SQL := "insert into user(username, password_hash, role, totp_secret, totp_enabled, recovery_codes) values(?, ?, ?, ?, ?, ?)"
+	result, err := tx.ExecContext(ctx, SQL, user.Username, user.PasswordHash, user.Role, user.TotpSecret, user.TotpEnabled, strings.Join(user.RecoveryCodes, ","))
+	if err != nil {
+		panic(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	user.Id = int(id)
+	return user
+}
+
+func (repository *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user domain.User) domain.User {
+	SQL := "update user set password_hash = ?, role = ?, totp_secret = ?, totp_enabled = ?, recovery_codes = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, user.PasswordHash, user.Role, user.TotpSecret, user.TotpEnabled, strings.Join(user.RecoveryCodes, ","), user.Id)
+	if err != nil {
+		panic(err)
+	}
+	return user
+}
+
+func (repository *UserRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, userId int) (domain.User, error) {
+	SQL := "select id, username, password_hash, role, totp_secret, totp_enabled, recovery_codes, totp_last_step from user where id = ?"
+	return repository.findOne(ctx, tx, SQL, userId)
+}
+
+func (repository *UserRepositoryImpl) FindByUsername(ctx context.Context, tx *sql.Tx, username string) (domain.User, error) {
+	SQL := "select id, username, password_hash, role, totp_secret, totp_enabled, recovery_codes, totp_last_step from user where username = ?"
+	return repository.findOne(ctx, tx, SQL, username)
+}
+
+func (repository *UserRepositoryImpl) UseTotpStep(ctx context.Context, tx *sql.Tx, userId int, step int64) bool {
+	SQL := "update user set totp_last_step = ? where id = ? and totp_last_step < ?"
+	result, err := tx.ExecContext(ctx, SQL, step, userId, step)
+	if err != nil {
+		panic(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+	return affected == 1
+}
+
+func (repository *UserRepositoryImpl) findOne(ctx context.Context, tx *sql.Tx, SQL string, arg interface{}) (domain.User, error) {
+	rows, err := tx.QueryContext(ctx, SQL, arg)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	user := domain.User{}
+	if rows.Next() {
+		var recoveryCodes string
+		err := rows.Scan(&user.Id, &user.Username, &user.PasswordHash, &user.Role, &user.TotpSecret, &user.TotpEnabled, &recoveryCodes, &user.TotpLastStep)
+		if err != nil {
+			panic(err)
+		}
+		if recoveryCodes != "" {
+			user.RecoveryCodes = strings.Split(recoveryCodes, ",")
+		}
+		return user, nil
+	} else {
+		return user, errors.New("user is not found")
+	}
+}
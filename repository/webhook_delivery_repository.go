@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+	"time"
+)
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery
+	Update(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery
+	FindById(ctx context.Context, tx *sql.Tx, webhookId int, deliveryId int) (domain.WebhookDelivery, error)
+	FindByWebhook(ctx context.Context, tx *sql.Tx, webhookId int, limit int) []domain.WebhookDelivery
+	ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, leaseUntil time.Time, limit int) []domain.WebhookJob
+	CreateAttempt(ctx context.Context, tx *sql.Tx, attempt domain.WebhookAttempt) domain.WebhookAttempt
+	FindAttempts(ctx context.Context, tx *sql.Tx, deliveryIds []int) map[int][]domain.WebhookAttempt
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"project-restful-api/model/domain"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const webhookDeliveryColumns = "d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at"
+
+type WebhookDeliveryRepositoryImpl struct {
+	QueryLog *QueryLog
+}
+
+func NewWebhookDeliveryRepository(queryLog *QueryLog) WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{
+		QueryLog: queryLog,
+	}
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	SQL := "insert into webhook_delivery(webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at) values(?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.Create").ExecContext(ctx, SQL, delivery.WebhookId, delivery.EventId, delivery.EventType, delivery.Payload, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.CreatedAt)
+	if err != nil {
+		panic(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	delivery.Id = int(id)
+	return delivery
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	var deliveredAt sql.NullTime
+	if !delivery.DeliveredAt.IsZero() {
+		deliveredAt = sql.NullTime{Time: delivery.DeliveredAt, Valid: true}
+	}
+	SQL := "update webhook_delivery set status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ? where id = ?"
+	_, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.Update").ExecContext(ctx, SQL, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode, truncate(delivery.LastError, 1000), deliveredAt, delivery.Id)
+	if err != nil {
+		panic(err)
+	}
+	return delivery
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, webhookId int, deliveryId int) (domain.WebhookDelivery, error) {
+	SQL := "select " + webhookDeliveryColumns + " from webhook_delivery d where d.webhook_id = ? and d.id = ?"
+	rows, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.FindById").QueryContext(ctx, SQL, webhookId, deliveryId)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanWebhookDelivery(rows), nil
+	} else {
+		return domain.WebhookDelivery{}, errors.New("webhook delivery is not found")
+	}
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) FindByWebhook(ctx context.Context, tx *sql.Tx, webhookId int, limit int) []domain.WebhookDelivery {
+	SQL := "select " + webhookDeliveryColumns + " from webhook_delivery d where d.webhook_id = ? order by d.id desc limit ?"
+	rows, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.FindByWebhook").QueryContext(ctx, SQL, webhookId, limit)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		deliveries = append(deliveries, scanWebhookDelivery(rows))
+	}
+	return deliveries
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, leaseUntil time.Time, limit int) []domain.WebhookJob {
+	SQL := "select " + webhookDeliveryColumns + ", w.url, w.secret from webhook_delivery d join webhook w on w.id = d.webhook_id " +
+		"where d.status = ? and d.next_attempt_at <= ? and w.active order by d.next_attempt_at limit ? for update of d skip locked"
+	rows, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.ClaimDue").QueryContext(ctx, SQL, domain.WebhookDeliveryPending, now, limit)
+	if err != nil {
+		panic(err)
+	}
+
+	var jobs []domain.WebhookJob
+	for rows.Next() {
+		job := domain.WebhookJob{}
+		job.Delivery = scanWebhookDelivery(rows, &job.Url, &job.Secret)
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, 0, len(jobs)+1)
+	ids = append(ids, leaseUntil)
+	for _, job := range jobs {
+		ids = append(ids, job.Delivery.Id)
+	}
+	SQL = "update webhook_delivery set next_attempt_at = ? where id in (?" + strings.Repeat(", ?", len(jobs)-1) + ")"
+	_, err = repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.ClaimDue").ExecContext(ctx, SQL, ids...)
+	if err != nil {
+		panic(err)
+	}
+	return jobs
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) CreateAttempt(ctx context.Context, tx *sql.Tx, attempt domain.WebhookAttempt) domain.WebhookAttempt {
+	SQL := "insert into webhook_attempt(delivery_id, status_code, error, duration_ms, attempted_at) values(?, ?, ?, ?, ?)"
+	result, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.CreateAttempt").ExecContext(ctx, SQL, attempt.DeliveryId, attempt.StatusCode, truncate(attempt.Error, 1000), attempt.Duration.Milliseconds(), attempt.AttemptedAt)
+	if err != nil {
+		panic(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	attempt.Id = int(id)
+	return attempt
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) FindAttempts(ctx context.Context, tx *sql.Tx, deliveryIds []int) map[int][]domain.WebhookAttempt {
+	attempts := map[int][]domain.WebhookAttempt{}
+	if len(deliveryIds) == 0 {
+		return attempts
+	}
+
+	args := make([]interface{}, len(deliveryIds))
+	for i, deliveryId := range deliveryIds {
+		args[i] = deliveryId
+	}
+	SQL := "select id, delivery_id, status_code, error, duration_ms, attempted_at from webhook_attempt where delivery_id in (?" + strings.Repeat(", ?", len(deliveryIds)-1) + ") order by id"
+	rows, err := repository.QueryLog.Wrap(tx, "WebhookDeliveryRepository.FindAttempts").QueryContext(ctx, SQL, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attempt := domain.WebhookAttempt{}
+		var durationMs int64
+		err := rows.Scan(&attempt.Id, &attempt.DeliveryId, &attempt.StatusCode, &attempt.Error, &durationMs, &attempt.AttemptedAt)
+		if err != nil {
+			panic(err)
+		}
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts[attempt.DeliveryId] = append(attempts[attempt.DeliveryId], attempt)
+	}
+	return attempts
+}
+
+func scanWebhookDelivery(rows *sql.Rows, extra ...interface{}) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{}
+	var deliveredAt sql.NullTime
+	destinations := []interface{}{&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload, &delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastStatusCode, &delivery.LastError, &delivery.CreatedAt, &deliveredAt}
+	err := rows.Scan(append(destinations, extra...)...)
+	if err != nil {
+		panic(err)
+	}
+	delivery.DeliveredAt = deliveredAt.Time
+	return delivery
+}
+
+func truncate(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+	for size > 0 && !utf8.RuneStart(value[size]) {
+		size--
+	}
+	return value[:size]
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook
+	Update(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook
+	Delete(ctx context.Context, tx *sql.Tx, webhook domain.Webhook)
+	FindById(ctx context.Context, tx *sql.Tx, webhookId int) (domain.Webhook, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.Webhook
+	FindActiveByEventType(ctx context.Context, tx *sql.Tx, eventType string) []domain.Webhook
+	RecordSuccess(ctx context.Context, tx *sql.Tx, webhookId int)
+	RecordFailure(ctx context.Context, tx *sql.Tx, webhookId int, disableAfter int)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"project-restful-api/model/domain"
+	"strings"
+)
+
+const webhookColumns = "id, url, secret, event_types, active, consecutive_failures, created_at"
+
+type WebhookRepositoryImpl struct {
+	QueryLog *QueryLog
+}
+
+func NewWebhookRepository(queryLog *QueryLog) WebhookRepository {
+	return &WebhookRepositoryImpl{
+		QueryLog: queryLog,
+	}
+}
+
+func (repository *WebhookRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook {
+	SQL := "insert into webhook(url, secret, event_types, active, consecutive_failures, created_at) values(?, ?, ?, ?, ?, ?)"
+	result, err := repository.QueryLog.Wrap(tx, "WebhookRepository.Create").ExecContext(ctx, SQL, webhook.Url, webhook.Secret, strings.Join(webhook.EventTypes, ","), webhook.Active, webhook.ConsecutiveFailures, webhook.CreatedAt)
+	if err != nil {
+		panic(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	webhook.Id = int(id)
+	return webhook
+}
+
+func (repository *WebhookRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook {
+	SQL := "update webhook set url = ?, secret = ?, event_types = ?, active = ?, consecutive_failures = ? where id = ?"
+	_, err := repository.QueryLog.Wrap(tx, "WebhookRepository.Update").ExecContext(ctx, SQL, webhook.Url, webhook.Secret, strings.Join(webhook.EventTypes, ","), webhook.Active, webhook.ConsecutiveFailures, webhook.Id)
+	if err != nil {
+		panic(err)
+	}
+	return webhook
+}
+
+func (repository *WebhookRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) {
+	SQL := "delete from webhook where id = ?"
+	_, err := repository.QueryLog.Wrap(tx, "WebhookRepository.Delete").ExecContext(ctx, SQL, webhook.Id)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (repository *WebhookRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, webhookId int) (domain.Webhook, error) {
+	SQL := "select " + webhookColumns + " from webhook where id = ?"
+	webhooks := repository.query(ctx, tx, "WebhookRepository.FindById", SQL, webhookId)
+	if len(webhooks) == 0 {
+		return domain.Webhook{}, errors.New("webhook is not found")
+	}
+	return webhooks[0], nil
+}
+
+func (repository *WebhookRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Webhook {
+	SQL := "select " + webhookColumns + " from webhook order by id"
+	return repository.query(ctx, tx, "WebhookRepository.FindAll", SQL)
+}
+
+func (repository *WebhookRepositoryImpl) FindActiveByEventType(ctx context.Context, tx *sql.Tx, eventType string) []domain.Webhook {
+	SQL := "select " + webhookColumns + " from webhook where active and find_in_set(?, event_types) > 0 order by id"
+	return repository.query(ctx, tx, "WebhookRepository.FindActiveByEventType", SQL, eventType)
+}
+
+func (repository *WebhookRepositoryImpl) RecordSuccess(ctx context.Context, tx *sql.Tx, webhookId int) {
+	SQL := "update webhook set consecutive_failures = 0 where id = ?"
+	_, err := repository.QueryLog.Wrap(tx, "WebhookRepository.RecordSuccess").ExecContext(ctx, SQL, webhookId)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (repository *WebhookRepositoryImpl) RecordFailure(ctx context.Context, tx *sql.Tx, webhookId int, disableAfter int) {
+	SQL := "update webhook set consecutive_failures = consecutive_failures + 1, active = active and consecutive_failures < ? where id = ?"
+	_, err := repository.QueryLog.Wrap(tx, "WebhookRepository.RecordFailure").ExecContext(ctx, SQL, disableAfter, webhookId)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (repository *WebhookRepositoryImpl) query(ctx context.Context, tx *sql.Tx, name string, SQL string, args ...interface{}) []domain.Webhook {
+	rows, err := repository.QueryLog.Wrap(tx, name).QueryContext(ctx, SQL, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		webhook := domain.Webhook{}
+		var eventTypes string
+		err := rows.Scan(&webhook.Id, &webhook.Url, &webhook.Secret, &eventTypes, &webhook.Active, &webhook.ConsecutiveFailures, &webhook.CreatedAt)
+		if err != nil {
+			panic(err)
+		}
+		webhook.EventTypes = strings.Split(eventTypes, ",")
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks
+}
@@ -11,4 +11,6 @@ type CategoryService interface {
 	Delete(ctx context.Context, categoryId int)
 	FindById(ctx context.Context, categoryId int) web.CategoryResponse
 	FindAll(ctx context.Context) []web.CategoryResponse
+	FindParents(ctx context.Context, categoryIds []int) map[int]web.CategoryResponse
+	FindTodos(ctx context.Context, categoryIds []int) map[int][]web.TodoResponse
 }
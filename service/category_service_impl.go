@@ -4,29 +4,40 @@ import (
 	"context"
 	"database/sql"
 	"project-restful-api/exception"
+	"project-restful-api/event"
 	"project-restful-api/helper"
 	"project-restful-api/model/domain"
 	"project-restful-api/model/web"
 	"project-restful-api/repository"
+	"project-restful-api/tracing"
 
 	"github.com/go-playground/validator/v10"
 )
 
 type CategoryServiceImpl struct {
 	CategoryRepository repository.CategoryRepository
+	TodoRepository repository.TodoRepository
 	DB *sql.DB
 	Validate *validator.Validate
+	Events event.Publisher
+	Webhooks WebhookQueue
 }
 
-func NewCategoryService(categoryRepository repository.CategoryRepository, DB *sql.DB, validate *validator.Validate) CategoryService {
+func NewCategoryService(categoryRepository repository.CategoryRepository, todoRepository repository.TodoRepository, DB *sql.DB, validate *validator.Validate, events event.Publisher, webhooks WebhookQueue) CategoryService {
 	return &CategoryServiceImpl{
 		CategoryRepository: categoryRepository,
+		TodoRepository:     todoRepository,
 		DB:                 DB,
 		Validate:           validate,
+		Events:             events,
+		Webhooks:           webhooks,
 	}
 }
 
 func (service *CategoryServiceImpl) Create(ctx context.Context, request web.CategoryCreateRequest) web.CategoryResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.Create")
+	defer span.End()
+
 	err := service.Validate.Struct(request)
 	helper.PanicIfError(err)
 	
@@ -35,16 +46,22 @@ func (service *CategoryServiceImpl) Create(ctx context.Context, request web.Cate
 		panic(err)
 	}
 	
-	defer helper.CommitOrRollback(tx)
-
 	category := domain.Category{
 		Name: request.Name,
 	}
+	defer helper.CommitOrRollback(tx, func() {
+		service.Events.Publish(domain.CategoryCreated, helper.ToCategoryResponse(category))
+	})
+
 	category = service.CategoryRepository.Create(ctx, tx, category)
+	service.Webhooks.Enqueue(ctx, tx, domain.CategoryCreated, helper.ToCategoryResponse(category))
 	return helper.ToCategoryResponse(category)
 }
 
 func (service *CategoryServiceImpl) Update(ctx context.Context, request web.CategoryUpdateRequest) web.CategoryResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.Update")
+	defer span.End()
+
 	err := service.Validate.Struct(request)
 	helper.PanicIfError(err)
 
@@ -52,33 +69,47 @@ func (service *CategoryServiceImpl) Update(ctx context.Context, request web.Cate
 	if err != nil {
 		panic(err)
 	}
-	defer helper.CommitOrRollback(tx)
+	var category domain.Category
+	defer helper.CommitOrRollback(tx, func() {
+		service.Events.Publish(domain.CategoryUpdated, helper.ToCategoryResponse(category))
+	})
 
-	category, err := service.CategoryRepository.FindById(ctx, tx, request.Id)
+	category, err = service.CategoryRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
 		panic(exception.NewNotFoundError(err.Error()))
 	}
 	category.Name = request.Name
 
 	category = service.CategoryRepository.Update(ctx, tx, category)
+	service.Webhooks.Enqueue(ctx, tx, domain.CategoryUpdated, helper.ToCategoryResponse(category))
 	return helper.ToCategoryResponse(category)
 }
 
 func (service *CategoryServiceImpl) Delete(ctx context.Context, categoryId int) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		panic(err)
 	}
-	defer helper.CommitOrRollback(tx)
+	var category domain.Category
+	defer helper.CommitOrRollback(tx, func() {
+		service.Events.Publish(domain.CategoryDeleted, helper.ToCategoryResponse(category))
+	})
 
-	category, err := service.CategoryRepository.FindById(ctx, tx, categoryId)
+	category, err = service.CategoryRepository.FindById(ctx, tx, categoryId)
 	if err != nil {
 		panic(exception.NewNotFoundError(err.Error()))
 	}
-	service.CategoryRepository.Delete(ctx, tx, category).
+	service.CategoryRepository.Delete(ctx, tx, category)
+	service.Webhooks.Enqueue(ctx, tx, domain.CategoryDeleted, helper.ToCategoryResponse(category))
 }
 
 func (service *CategoryServiceImpl) FindById(ctx context.Context, categoryId int) web.CategoryResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindById")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		panic(err)
@@ -93,6 +124,9 @@ func (service *CategoryServiceImpl) FindById(ctx context.Context, categoryId int
 }
 
 func (service *CategoryServiceImpl) FindAll(ctx context.Context) []web.CategoryResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindAll")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
@@ -100,3 +134,34 @@ func (service *CategoryServiceImpl) FindAll(ctx context.Context) []web.CategoryR
 	categories := service.CategoryRepository.FindAll(ctx, tx)
 	return helper.ToCategoryResponses(categories)
 }
+
+func (service *CategoryServiceImpl) FindParents(ctx context.Context, categoryIds []int) map[int]web.CategoryResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindParents")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	parents := map[int]web.CategoryResponse{}
+	for categoryId, parent := range service.CategoryRepository.FindParents(ctx, tx, categoryIds) {
+		parents[categoryId] = helper.ToCategoryResponse(parent)
+	}
+	return parents
+}
+
+func (service *CategoryServiceImpl) FindTodos(ctx context.Context, categoryIds []int) map[int][]web.TodoResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindTodos")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	todos := service.TodoRepository.FindByCategoryIds(ctx, tx, categoryIds)
+	todoResponses := map[int][]web.TodoResponse{}
+	for _, categoryId := range categoryIds {
+		todoResponses[categoryId] = helper.ToTodoResponses(todos[categoryId])
+	}
+	return todoResponses
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"project-restful-api/model/web"
+)
+
+type ReadinessProbe interface {
+	Ready() bool
+}
+
+type HealthService interface {
+	Readiness(ctx context.Context) web.ReadinessResponse
+	Status(ctx context.Context) web.StatusResponse
+}
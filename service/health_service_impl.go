@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/repository"
+	"strconv"
+	"time"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthServiceImpl struct {
+	DB             *sql.DB
+	ReadinessProbe ReadinessProbe
+	BuildInfo      domain.BuildInfo
+}
+
+func NewHealthService(DB *sql.DB, readinessProbe ReadinessProbe, buildInfo domain.BuildInfo) HealthService {
+	return &HealthServiceImpl{
+		DB:             DB,
+		ReadinessProbe: readinessProbe,
+		BuildInfo:      buildInfo,
+	}
+}
+
+func (service *HealthServiceImpl) Readiness(ctx context.Context) web.ReadinessResponse {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	response := web.ReadinessResponse{
+		Ready:  true,
+		Checks: map[string]string{},
+	}
+	fail := func(check string, reason string) {
+		response.Ready = false
+		response.Checks[check] = reason
+	}
+
+	if service.ReadinessProbe.Ready() {
+		response.Checks["server"] = "ok"
+	} else {
+		fail("server", "shutting down")
+	}
+
+	if err := service.DB.PingContext(ctx); err != nil {
+		fail("database", err.Error())
+		fail("migrations", "database is unavailable")
+		return response
+	}
+	response.Checks["database"] = "ok"
+
+	pending, err := repository.PendingMigrations(ctx, service.DB)
+	switch {
+	case err != nil:
+		fail("migrations", err.Error())
+	case pending > 0:
+		fail("migrations", strconv.Itoa(pending)+" pending")
+	default:
+		response.Checks["migrations"] = "ok"
+	}
+	return response
+}
+
+func (service *HealthServiceImpl) Status(ctx context.Context) web.StatusResponse {
+	stats := service.DB.Stats()
+	return web.StatusResponse{
+		Version:   service.BuildInfo.Version,
+		StartedAt: service.BuildInfo.StartedAt,
+		Uptime:    time.Since(service.BuildInfo.StartedAt).Round(time.Second).String(),
+		Database: web.DatabaseStatsResponse{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDuration:       stats.WaitDuration.String(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		},
+	}
+}
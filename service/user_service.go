@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"project-restful-api/model/web"
+)
+
+type UserService interface {
+	Create(ctx context.Context, request web.UserCreateRequest) web.UserResponse
+	Login(ctx context.Context, request web.LoginRequest) web.LoginResponse
+	EnrollTotp(ctx context.Context, userId int) web.TotpEnrollResponse
+	ActivateTotp(ctx context.Context, userId int, request web.TotpActivateRequest) web.UserResponse
+}
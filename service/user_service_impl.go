@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/repository"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	totpIssuer        = "Todolist"
+	sessionLifetime   = 12 * time.Hour
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+// dummyPasswordHash is compared against when a username does not exist, so a
+// failed login takes as long for unknown users as for wrong passwords.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type UserServiceImpl struct {
+	UserRepository    repository.UserRepository
+	SessionRepository repository.SessionRepository
+	DB                *sql.DB
+	Validate          *validator.Validate
+	Guard             *middleware.AuthGuard
+}
+
+func NewUserService(userRepository repository.UserRepository, sessionRepository repository.SessionRepository, DB *sql.DB, validate *validator.Validate, guard *middleware.AuthGuard) UserService {
+	return &UserServiceImpl{
+		UserRepository:    userRepository,
+		SessionRepository: sessionRepository,
+		DB:                DB,
+		Validate:          validate,
+		Guard:             guard,
+	}
+}
+
+func (service *UserServiceImpl) Create(ctx context.Context, request web.UserCreateRequest) web.UserResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user := domain.User{
+		Username:     request.Username,
+		PasswordHash: string(passwordHash),
+		Role:         request.Role,
+	}
+	user = service.UserRepository.Create(ctx, tx, user)
+	return helper.ToUserResponse(user)
+}
+
+func (service *UserServiceImpl) Login(ctx context.Context, request web.LoginRequest) web.LoginResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	info := helper.RequestInfoFromContext(ctx)
+	subjects := []string{service.Guard.IpSubject(info.ClientIP), service.Guard.AccountSubject(info.ClientIP, request.Username)}
+	if lockedFor := service.Guard.LockedFor(subjects); lockedFor > 0 {
+		retryAfter := strconv.Itoa(int((lockedFor + time.Second - 1) / time.Second))
+		panic(exception.NewTooManyRequestsError("too many failed login attempts, retry in " + retryAfter + " seconds"))
+	}
+	reject := func(reason string) {
+		service.Guard.RecordFailure(domain.AuthFailure{
+			ClientIP: info.ClientIP,
+			Method:   "POST",
+			Path:     info.Route,
+			Reason:   reason,
+		}, subjects)
+		panic(exception.NewUnauthorizedError(reason))
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user, err := service.UserRepository.FindByUsername(ctx, tx, request.Username)
+	if err != nil {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(request.Password))
+		reject("username or password is wrong")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)) != nil {
+		reject("username or password is wrong")
+	}
+
+	twoFactorVerified := false
+	if user.TotpEnabled {
+		switch {
+		case request.TotpCode != "":
+			step, ok := helper.VerifyTotpStep(user.TotpSecret, request.TotpCode, time.Now())
+			if !ok || !service.UserRepository.UseTotpStep(ctx, tx, user.Id, step) {
+				reject("totp code is invalid")
+			}
+		case request.RecoveryCode != "":
+			if !useRecoveryCode(&user, request.RecoveryCode) {
+				reject("recovery code is invalid")
+			}
+			service.UserRepository.Update(ctx, tx, user)
+		default:
+			panic(exception.NewUnauthorizedError("totp code is required"))
+		}
+		twoFactorVerified = true
+	}
+	service.Guard.RecordSuccess(subjects[1:])
+
+	session := service.SessionRepository.Save(domain.Session{
+		Token:             helper.RandomToken(32),
+		UserId:            user.Id,
+		Username:          user.Username,
+		Role:              user.Role,
+		TwoFactorVerified: twoFactorVerified,
+		ExpiresAt:         time.Now().Add(sessionLifetime),
+	})
+	return web.LoginResponse{
+		Token:             session.Token,
+		ExpiresAt:         session.ExpiresAt,
+		TwoFactorVerified: session.TwoFactorVerified,
+	}
+}
+
+func (service *UserServiceImpl) EnrollTotp(ctx context.Context, userId int) web.TotpEnrollResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user, err := service.UserRepository.FindById(ctx, tx, userId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	if user.TotpEnabled {
+		panic(exception.NewForbiddenError("totp is already enabled"))
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	user.RecoveryCodes = make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		code := helper.RandomToken(recoveryCodeBytes)
+		recoveryCodes[i] = code[:5] + "-" + code[5:10] + "-" + code[10:15] + "-" + code[15:]
+		hash, err := bcrypt.GenerateFromPassword([]byte(recoveryCodes[i]), bcrypt.DefaultCost)
+		helper.PanicIfError(err)
+		user.RecoveryCodes[i] = string(hash)
+	}
+	user.TotpSecret = helper.GenerateTotpSecret()
+	service.UserRepository.Update(ctx, tx, user)
+
+	return web.TotpEnrollResponse{
+		Secret:          user.TotpSecret,
+		ProvisioningUri: helper.TotpProvisioningUri(totpIssuer, user.Username, user.TotpSecret),
+		RecoveryCodes:   recoveryCodes,
+	}
+}
+
+func (service *UserServiceImpl) ActivateTotp(ctx context.Context, userId int, request web.TotpActivateRequest) web.UserResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user, err := service.UserRepository.FindById(ctx, tx, userId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	if user.TotpSecret == "" {
+		panic(exception.NewForbiddenError("totp enrollment has not been started"))
+	}
+	step, ok := helper.VerifyTotpStep(user.TotpSecret, request.Code, time.Now())
+	if !ok || !service.UserRepository.UseTotpStep(ctx, tx, user.Id, step) {
+		panic(exception.NewUnauthorizedError("totp code is invalid"))
+	}
+
+	user.TotpEnabled = true
+	user = service.UserRepository.Update(ctx, tx, user)
+	return helper.ToUserResponse(user)
+}
+
+func useRecoveryCode(user *domain.User, code string) bool {
+	code = strings.TrimSpace(strings.ToLower(code))
+	for i, stored := range user.RecoveryCodes {
+		if bcrypt.CompareHashAndPassword([]byte(stored), []byte(code)) == nil {
+			user.RecoveryCodes = append(user.RecoveryCodes[:i], user.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
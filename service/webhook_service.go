@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"time"
+)
+
+type WebhookQueue interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, eventType string, data interface{})
+}
+
+type WebhookService interface {
+	WebhookQueue
+	Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookResponse
+	Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse
+	Delete(ctx context.Context, webhookId int)
+	FindById(ctx context.Context, webhookId int) web.WebhookResponse
+	FindAll(ctx context.Context) []web.WebhookResponse
+	FindDeliveries(ctx context.Context, request web.WebhookDeliveryQuery) []web.WebhookDeliveryResponse
+	Redeliver(ctx context.Context, request web.WebhookDeliveryIdRequest) web.WebhookDeliveryResponse
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) []domain.WebhookJob
+	RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/repository"
+	"project-restful-api/tracing"
+	"project-restful-api/webhook"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookServiceImpl struct {
+	WebhookRepository         repository.WebhookRepository
+	WebhookDeliveryRepository repository.WebhookDeliveryRepository
+	DB                        *sql.DB
+	Validate                  *validator.Validate
+	Config                    webhook.Config
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, DB *sql.DB, validate *validator.Validate, config webhook.Config) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookRepository:         webhookRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		DB:                        DB,
+		Validate:                  validate,
+		Config:                    config,
+	}
+}
+
+func (service *WebhookServiceImpl) Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+	validateWebhookUrl(request.Url)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	hook := domain.Webhook{
+		Url:        request.Url,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}
+	hook = service.WebhookRepository.Create(ctx, tx, hook)
+	return helper.ToWebhookResponse(hook)
+}
+
+func (service *WebhookServiceImpl) Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.Update")
+	defer span.End()
+
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+	validateWebhookUrl(request.Url)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	hook, err := service.WebhookRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	if request.Active && !hook.Active {
+		hook.ConsecutiveFailures = 0
+	}
+	hook.Url = request.Url
+	hook.EventTypes = request.EventTypes
+	hook.Active = request.Active
+	if request.Secret != "" {
+		hook.Secret = request.Secret
+	}
+
+	hook = service.WebhookRepository.Update(ctx, tx, hook)
+	return helper.ToWebhookResponse(hook)
+}
+
+func (service *WebhookServiceImpl) Delete(ctx context.Context, webhookId int) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	hook, err := service.WebhookRepository.FindById(ctx, tx, webhookId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	service.WebhookRepository.Delete(ctx, tx, hook)
+}
+
+func (service *WebhookServiceImpl) FindById(ctx context.Context, webhookId int) web.WebhookResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.FindById")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	hook, err := service.WebhookRepository.FindById(ctx, tx, webhookId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return helper.ToWebhookResponse(hook)
+}
+
+func (service *WebhookServiceImpl) FindAll(ctx context.Context) []web.WebhookResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.FindAll")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	hooks := service.WebhookRepository.FindAll(ctx, tx)
+	return helper.ToWebhookResponses(hooks)
+}
+
+func (service *WebhookServiceImpl) FindDeliveries(ctx context.Context, request web.WebhookDeliveryQuery) []web.WebhookDeliveryResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.FindDeliveries")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	if _, err := service.WebhookRepository.FindById(ctx, tx, request.WebhookId); err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	deliveries := service.WebhookDeliveryRepository.FindByWebhook(ctx, tx, request.WebhookId, request.Limit)
+
+	deliveryIds := make([]int, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryIds[i] = delivery.Id
+	}
+	attempts := service.WebhookDeliveryRepository.FindAttempts(ctx, tx, deliveryIds)
+	return helper.ToWebhookDeliveryResponses(deliveries, attempts)
+}
+
+func (service *WebhookServiceImpl) Redeliver(ctx context.Context, request web.WebhookDeliveryIdRequest) web.WebhookDeliveryResponse {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	delivery, err := service.WebhookDeliveryRepository.FindById(ctx, tx, request.WebhookId, request.DeliveryId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	hook, err := service.WebhookRepository.FindById(ctx, tx, request.WebhookId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	if !hook.Active {
+		panic(exception.NewConflictError("webhook is disabled, reactivate it before redelivering"))
+	}
+
+	now := time.Now()
+	redelivery := domain.WebhookDelivery{
+		WebhookId:     delivery.WebhookId,
+		EventId:       delivery.EventId,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	redelivery = service.WebhookDeliveryRepository.Create(ctx, tx, redelivery)
+	return helper.ToWebhookDeliveryResponse(redelivery, nil)
+}
+
+func (service *WebhookServiceImpl) Enqueue(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) {
+	hooks := service.WebhookRepository.FindActiveByEventType(ctx, tx, eventType)
+	if len(hooks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	payload := web.WebhookPayload{
+		Id:        "evt_" + helper.RandomToken(12),
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      data,
+	}
+	encoded, err := json.Marshal(payload)
+	helper.PanicIfError(err)
+
+	for _, hook := range hooks {
+		service.WebhookDeliveryRepository.Create(ctx, tx, domain.WebhookDelivery{
+			WebhookId:     hook.Id,
+			EventId:       payload.Id,
+			EventType:     eventType,
+			Payload:       string(encoded),
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+}
+
+func (service *WebhookServiceImpl) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) []domain.WebhookJob {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := time.Now()
+	return service.WebhookDeliveryRepository.ClaimDue(ctx, tx, now, now.Add(lease), limit)
+}
+
+func (service *WebhookServiceImpl) RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt) {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+	service.WebhookDeliveryRepository.CreateAttempt(ctx, tx, attempt)
+	if attempt.Error == "" {
+		service.WebhookRepository.RecordSuccess(ctx, tx, delivery.WebhookId)
+	} else {
+		service.WebhookRepository.RecordFailure(ctx, tx, delivery.WebhookId, service.Config.DisableAfter)
+	}
+}
+
+func validateWebhookUrl(rawUrl string) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		panic(helper.BindError{Source: "body", Field: "url", Message: "must be an absolute http or https URL"})
+	}
+}
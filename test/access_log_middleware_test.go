@@ -0,0 +1,75 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"project-restful-api/helper"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIdPropagated(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	request.Header.Add("X-Request-ID", "abc-123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, "abc-123", recorder.Header().Get("X-Request-ID"))
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	request.Header.Add("X-Request-ID", "not valid\n")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 32, len(recorder.Header().Get("X-Request-ID")))
+}
+
+func TestAccessLogAndInternalServerError(t *testing.T) {
+	var output bytes.Buffer
+	helper.Logger.SetOutput(&output)
+	defer helper.Logger.SetOutput(os.Stdout)
+
+	db := setupTestDB()
+	router := setupRouter(db)
+	db.Close()
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories/1", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("X-Request-ID", "req-500")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 500, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+
+	var responseBody map[string]interface{}
+
+	err := json.Unmarshal(body, &responseBody)
+	if err != nil {
+		panic(err)
+	}
+	assert.Equal(t, map[string]interface{}{"request_id": "req-500"}, responseBody["data"])
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	var errorLog map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &errorLog)
+	assert.Equal(t, "req-500", errorLog["request_id"])
+	assert.Contains(t, errorLog["stack"], "goroutine")
+
+	var accessLog map[string]interface{}
+	json.Unmarshal([]byte(lines[1]), &accessLog)
+	assert.Equal(t, "/api/v1/categories/:categoryId", accessLog["route"])
+	assert.Equal(t, float64(500), accessLog["status"])
+	assert.Equal(t, "default", accessLog["principal"])
+}
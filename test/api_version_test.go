@@ -0,0 +1,107 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestedApiVersion(t *testing.T) {
+	assert.Equal(t, "v2", middleware.RequestedApiVersion("application/vnd.todolist.v2+json"))
+	assert.Equal(t, "v2", middleware.RequestedApiVersion("text/html, application/vnd.todolist+json; version=2"))
+	assert.Equal(t, "", middleware.RequestedApiVersion("application/json"))
+}
+
+func TestApiVersionRouting(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/categories/abc", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "v1", recorder.Header().Get("API-Version"))
+	assert.Equal(t, "@1790812800", recorder.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", recorder.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2/categories/abc>; rel="successor-version"`, recorder.Header().Get("Link"))
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories/abc", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, "v1", recorder.Header().Get("API-Version"))
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories/abc", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("Accept", "application/vnd.todolist.v2+json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "v2", recorder.Header().Get("API-Version"))
+	assert.Equal(t, "", recorder.Header().Get("Deprecation"))
+	assert.Contains(t, recorder.Body.String(), `"error":{"code":400`)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Accept", "application/vnd.todolist.v9+json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 406, recorder.Code)
+}
+
+func TestApiVersionEnvelope(t *testing.T) {
+	router := httprouter.New()
+	for _, version := range []string{"v1", "v2"} {
+		router.GET("/api/"+version+"/categories/:categoryId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			if params.ByName("categoryId") != "1" {
+				panic(exception.NewNotFoundError("category is not found"))
+			}
+			helper.WriteToResponseBody(writer, web.WebResponse{
+				Code:   200,
+				Status: "OK",
+				Data:   web.CategoryResponse{Id: 1, Name: "Gadget"},
+			})
+		})
+	}
+	router.PanicHandler = exception.ErrorHandler
+	apiVersions := app.NewApiVersions()
+	handler := middleware.NewApiVersionMiddleware(router, apiVersions.Resources, apiVersions.Policies, apiVersions.Default)
+
+	serve := func(path string, accept string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000"+path, nil)
+		if accept != "" {
+			request.Header.Add("Accept", accept)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve("/api/v1/categories/1", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"code":200,"status":"OK","data":{"id":1,"name":"Gadget"}}`, recorder.Body.String())
+
+	recorder = serve("/api/v2/categories/1", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"data":{"id":1,"name":"Gadget"}}`, recorder.Body.String())
+
+	recorder = serve("/api/categories/1", "application/vnd.todolist.v2+json")
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"data":{"id":1,"name":"Gadget"}}`, recorder.Body.String())
+
+	recorder = serve("/api/v2/categories/2", "")
+	assert.Equal(t, 404, recorder.Code)
+	assert.JSONEq(t, `{"error":{"code":404,"status":"NOT FOUND","message":"category is not found"}}`, recorder.Body.String())
+
+	recorder = serve("/api/categories/2", "")
+	assert.Equal(t, 404, recorder.Code)
+	assert.JSONEq(t, `{"code":404,"status":"NOT FOUND","data":"category is not found"}`, recorder.Body.String())
+}
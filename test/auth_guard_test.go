@@ -0,0 +1,89 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthLockoutAfterRepeatedFailures(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	for i := 0; i < 5; i++ {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+		request.Header.Add("X-API-Key", "SALAH")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, 401, recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 429, response.StatusCode)
+	assert.Equal(t, "30", response.Header.Get("Retry-After"))
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/admin/auth-failures?ip=192.0.2.1", nil)
+	request.RemoteAddr = "198.51.100.1:1234"
+	request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response = recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+
+	var responseBody map[string]interface{}
+
+	err := json.Unmarshal(body, &responseBody)
+	if err != nil {
+		panic(err)
+	}
+
+	failures := responseBody["data"].([]interface{})
+	assert.Equal(t, 5, len(failures))
+	assert.Equal(t, "SALAH", failures[0].(map[string]interface{})["key_prefix"])
+}
+
+func TestAdminRouteForbiddenForUserRole(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/admin/auth-failures", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 403, recorder.Code)
+}
+
+func TestAuthLockoutDoesNotSpreadToKeysSharingPrefix(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	for i := 0; i < 5; i++ {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+		request.RemoteAddr = "203.0.113.9:1234"
+		request.Header.Add("X-API-Key", "RAHASIA_TEBAKAN_"+strconv.Itoa(i))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, 401, recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/admin/auth-failures", nil)
+	request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+}
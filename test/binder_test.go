@@ -0,0 +1,67 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindTestRequest struct {
+	Id      int      `path:"categoryId" validate:"required,min=1"`
+	Limit   int      `query:"limit" validate:"max=100"`
+	Tags    []string `query:"tag"`
+	Verbose bool     `query:"verbose"`
+	TraceId string   `header:"X-Trace-Id"`
+	Name    string   `json:"name" validate:"required"`
+}
+
+func setupBinderRouter(result *bindTestRequest) http.Handler {
+	validate := validator.New()
+	router := httprouter.New()
+	router.PUT("/items/:categoryId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		*result = bindTestRequest{Limit: 10}
+		helper.Bind(request, params, result, validate)
+	})
+	router.PanicHandler = exception.ErrorHandler
+	return router
+}
+
+func TestBindPathQueryHeaderAndBody(t *testing.T) {
+	var result bindTestRequest
+	router := setupBinderRouter(&result)
+
+	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/items/7?tag=a&tag=b&verbose=true", strings.NewReader(`{"name": "Gadget"}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-Trace-Id", "abc")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, bindTestRequest{Id: 7, Limit: 10, Tags: []string{"a", "b"}, Verbose: true, TraceId: "abc", Name: "Gadget"}, result)
+}
+
+func TestBindErrors(t *testing.T) {
+	var result bindTestRequest
+	router := setupBinderRouter(&result)
+
+	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/items/abc", strings.NewReader(`{"name": "Gadget"}`))
+	request.Header.Add("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "path parameter categoryId must be an integer")
+
+	request = httptest.NewRequest(http.MethodPut, "http://localhost:3000/items/7?limit=500", strings.NewReader(`{"name": "Gadget"}`))
+	request.Header.Add("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "'Limit' failed on the 'max' tag")
+}
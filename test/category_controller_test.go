@@ -15,6 +15,7 @@ import (
 	"project-restful-api/model/domain"
 	"project-restful-api/repository"
 	"project-restful-api/service"
+	"project-restful-api/tracing"
 	"strconv"
 	"strings"
 	"testing"
@@ -26,29 +27,62 @@ import (
 )
 
 func setupTestDB() *sql.DB {
-	db, err := sql.Open("mysql", "root:Ulang.ko.PutusAsa.daa.mang.17@tcp(localhost:3306)/belajar_golang_restful_api_test")
+	db, err := sql.Open("mysql", "root:Ulang.ko.PutusAsa.daa.mang.17@tcp(localhost:3306)/belajar_golang_restful_api_test?parseTime=true")
 	helper.PanicIfError(err)
 
 	db.SetMaxIdleConns(5)
 	db.SetMaxOpenConns(20)
 	db.SetConnMaxLifetime(60 * time.Minute)
 	db.SetConnMaxIdleTime(10 * time.Minute)
+
+	repository.Migrate(context.Background(), db)
 	return db
 }
 
 func setupRouter(db *sql.DB) http.Handler {
 
 	validate := validator.New()
-	categoryRepository := repository.NewCategoryRepository()
-	categoryService := service.NewCategoryService(categoryRepository, db, validate)
-	categoryController := controller.NewCategoryController(categoryService)
-
-	router := app.NewRouter(categoryController)
-	return middleware.NewAuthMiddleware(router)
+	queryLog := repository.NewQueryLog(app.NewQueryLogConfig())
+	categoryRepository := repository.NewCategoryRepository(queryLog)
+	broker := app.NewEventBroker()
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(queryLog), repository.NewWebhookDeliveryRepository(queryLog), db, validate, app.NewWebhookConfig())
+	categoryService := service.NewCategoryService(categoryRepository, repository.NewTodoRepository(queryLog), db, validate, broker, webhookService)
+	categoryController := controller.NewCategoryController(categoryService, validate)
+	graphqlController := controller.NewGraphqlController(controller.NewGraphqlSchema(categoryService), app.NewGraphqlLimits())
+	rpcController := controller.NewRpcController(categoryService, validate)
+	eventController := controller.NewEventController(broker, app.NewEventStreamConfig(app.NewServerConfig()))
+	webSocketController := controller.NewWebSocketController(categoryService, broker, app.NewWebSocketConfig(app.NewCorsConfig()))
+	webhookController := controller.NewWebhookController(webhookService, validate)
+
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	userRepository := repository.NewUserRepository()
+	sessionRepository := repository.NewSessionRepository()
+	userService := service.NewUserService(userRepository, sessionRepository, db, validate, authGuard)
+	authController := controller.NewAuthController(userService)
+
+	healthService := service.NewHealthService(db, app.NewReadiness(), app.NewBuildInfo())
+	healthController := controller.NewHealthController(healthService)
+
+	twoFactorPolicy := app.NewTwoFactorPolicy()
+	adminController := controller.NewAdminController(authGuard, twoFactorPolicy, userService, queryLog, validate)
+	authMiddleware := middleware.NewAuthMiddleware(app.NewApiKeys(), sessionRepository, twoFactorPolicy, authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(middleware.NewMemoryRateLimitStore(), app.NewTrustedProxies())
+	router := app.NewRouter(categoryController, graphqlController, rpcController, eventController, webSocketController, webhookController, authController, adminController, healthController, authMiddleware, rateLimitMiddleware, app.NewRateLimits(), app.NewApiVersions())
+	return app.NewHandler(router, app.NewTrustedProxies(), tracing.Default(), app.NewServerConfig(), app.NewCorsConfig(), app.NewCompressionConfig(), app.NewApiVersions())
 }
 
 func truncateCategory(db *sql.DB) {
-	db.Exec("TRUNCATE category")
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0")
+	conn.ExecContext(ctx, "TRUNCATE todo")
+	conn.ExecContext(ctx, "TRUNCATE category")
+	conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
 }
 
 func TestCreateCategorySuccess(t *testing.T) {
@@ -125,7 +159,7 @@ func TestUpdateCategorySuccess(t *testing.T) {
 	truncateCategory(db)
 
 	tx, _ := db.Begin()
-	categoryRepository := repository.NewCategoryRepository()
+	categoryRepository := repository.NewCategoryRepository(repository.NewQueryLog(app.NewQueryLogConfig()))
 	category := categoryRepository.Create(context.Background(), tx, domain.Category{
 		Name: "Gadget",
 	})
@@ -169,7 +203,7 @@ func TestUpdateCategoryFailed(t *testing.T) {
 	truncateCategory(db)
 
 	tx, _ := db.Begin()
-	categoryRepository := repository.NewCategoryRepository()
+	categoryRepository := repository.NewCategoryRepository(repository.NewQueryLog(app.NewQueryLogConfig()))
 	category := categoryRepository.Create(context.Background(), tx, domain.Category{
 		Name: "Gadget",
 	})
@@ -212,7 +246,7 @@ func TestGetCategorySuccess(t *testing.T) {
 	
 	tx, _ := db.Begin()
 	
-	categoryRepository := repository.NewCategoryRepository()
+	categoryRepository := repository.NewCategoryRepository(repository.NewQueryLog(app.NewQueryLogConfig()))
 	category := categoryRepository.Create(context.Background(), tx, domain.Category{
 		Name: "Gadget",
 	})
@@ -285,7 +319,7 @@ func TestDeleteCategorySuccess(t *testing.T) {
 
 	tx, _ := db.Begin()
 	
-	categoryRepository := repository.NewCategoryRepository()
+	categoryRepository := repository.NewCategoryRepository(repository.NewQueryLog(app.NewQueryLogConfig()))
 	
 	category := categoryRepository.Create(context.Background(), tx, domain.Category{
 		Name: "Gadget",
@@ -359,7 +393,7 @@ func TestListCategoriesSuccess(t *testing.T) {
 	truncateCategory(db)
 
 	tx, _ := db.Begin()
-	categoryRepository := repository.NewCategoryRepository()
+	categoryRepository := repository.NewCategoryRepository(repository.NewQueryLog(app.NewQueryLogConfig()))
 	category1 := categoryRepository.Create(context.Background(), tx, domain.Category{
 		Name: "Gadget",
 	})
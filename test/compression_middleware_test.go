@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/middleware"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCompressionHandler(body string) http.Handler {
+	return middleware.NewCompressionMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Body != nil {
+			received, _ := io.ReadAll(request.Body)
+			if len(received) > 0 {
+				body = string(received)
+			}
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("ETag", `"v1"`)
+		writer.Write([]byte(body))
+	}), app.NewCompressionConfig())
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	assert.Equal(t, "gzip", middleware.NegotiateEncoding("gzip, deflate, br"))
+	assert.Equal(t, "deflate", middleware.NegotiateEncoding("gzip;q=0.5, deflate"))
+	assert.Equal(t, "deflate", middleware.NegotiateEncoding("gzip;q=0, *"))
+	assert.Equal(t, "", middleware.NegotiateEncoding("br, identity"))
+	assert.Equal(t, "", middleware.NegotiateEncoding(""))
+	assert.Equal(t, "zstd", middleware.NegotiateEncoding("zstd, br"))
+	assert.Equal(t, "zstd", middleware.NegotiateEncoding("gzip;q=0.5, zstd"))
+}
+
+func TestCompressLargeResponse(t *testing.T) {
+	body := strings.Repeat(`{"id":1,"name":"Gadget"},`, 100)
+	handler := setupCompressionHandler(body)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+	assert.Equal(t, `W/"v1"`, recorder.Header().Get("ETag"))
+	assert.Less(t, recorder.Body.Len(), len(body))
+
+	reader, err := gzip.NewReader(recorder.Body)
+	assert.Nil(t, err)
+	decoded, _ := io.ReadAll(reader)
+	assert.Equal(t, body, string(decoded))
+}
+
+func TestSkipCompressionForSmallResponse(t *testing.T) {
+	handler := setupCompressionHandler(`{"code":200}`)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, `"v1"`, recorder.Header().Get("ETag"))
+	assert.Equal(t, `{"code":200}`, recorder.Body.String())
+}
+
+func TestGzipEncodedRequestBody(t *testing.T) {
+	handler := setupCompressionHandler("")
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write([]byte(`{"name":"Gadget"}`))
+	gzipWriter.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/categories", &compressed)
+	request.Header.Add("Content-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, `{"name":"Gadget"}`, recorder.Body.String())
+
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/categories", strings.NewReader("not gzip"))
+	request.Header.Add("Content-Encoding", "gzip")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, 400, recorder.Code)
+}
+
+func TestZstdResponseAndRequestBody(t *testing.T) {
+	body := strings.Repeat(`{"id":1,"name":"Gadget"},`, 100)
+	handler := setupCompressionHandler(body)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Accept-Encoding", "zstd")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "zstd", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, `W/"v1"`, recorder.Header().Get("ETag"))
+	decoder, err := zstd.NewReader(recorder.Body)
+	assert.Nil(t, err)
+	decoded, _ := io.ReadAll(decoder)
+	decoder.Close()
+	assert.Equal(t, body, string(decoded))
+
+	encoder, _ := zstd.NewWriter(nil)
+	compressed := encoder.EncodeAll([]byte(`{"name":"Gadget"}`), nil)
+	encoder.Close()
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/categories", bytes.NewReader(compressed))
+	request.Header.Add("Content-Encoding", "zstd")
+	recorder = httptest.NewRecorder()
+	setupCompressionHandler("").ServeHTTP(recorder, request)
+	assert.Equal(t, `{"name":"Gadget"}`, recorder.Body.String())
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/middleware"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorsPreflightBeforeAuth(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodOptions, "http://localhost:3000/api/categories/1", nil)
+	request.Header.Add("Origin", "http://localhost:5173")
+	request.Header.Add("Access-Control-Request-Method", "PUT")
+	request.Header.Add("Access-Control-Request-Headers", "content-type, x-api-key")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 204, response.StatusCode)
+	assert.Equal(t, "http://localhost:5173", response.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", response.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, response.Header.Get("Access-Control-Allow-Headers"), "X-API-Key")
+	assert.Equal(t, "600", response.Header.Get("Access-Control-Max-Age"))
+	assert.Contains(t, response.Header.Values("Vary"), "Origin")
+}
+
+func TestCorsRejectedOrigin(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodOptions, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Origin", "https://evil.example.com")
+	request.Header.Add("Access-Control-Request-Method", "GET")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 403, recorder.Code)
+	assert.Equal(t, "", recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsExposedHeaders(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	request.Header.Add("Origin", "http://127.0.0.1:8080")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "http://127.0.0.1:8080", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, recorder.Header().Get("Access-Control-Expose-Headers"), "RateLimit-Remaining")
+	assert.Contains(t, recorder.Header().Get("Access-Control-Expose-Headers"), "ETag")
+}
+
+func TestCorsWildcardIsNotReflectedWithCredentials(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	config := middleware.CorsConfig{
+		AllowedOrigins:   []string{"*"},
+		AllowedMethods:   []string{http.MethodGet},
+		AllowCredentials: true,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Origin", "https://evil.example.com")
+	recorder := httptest.NewRecorder()
+	middleware.NewCorsMiddleware(handler, config).ServeHTTP(recorder, request)
+
+	assert.Equal(t, "", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "", recorder.Header().Get("Access-Control-Allow-Credentials"))
+	assert.False(t, config.AllowsOrigin("https://evil.example.com"))
+
+	config.AllowCredentials = false
+	assert.True(t, config.AllowsOrigin("https://evil.example.com"))
+}
+
+func TestCorsExposesDeprecationAndTraceHeaders(t *testing.T) {
+	exposed := app.NewCorsConfig().ExposedHeaders
+	for _, name := range []string{"Deprecation", "Sunset", "Link", "traceparent"} {
+		assert.Contains(t, exposed, name)
+	}
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandlerMatchesErrorStatus(t *testing.T) {
+	cases := []struct {
+		err    interface{}
+		code   int
+		status string
+	}{
+		{exception.NewNotFoundError("category is not found"), http.StatusNotFound, "NOT FOUND"},
+		{exception.NewUnauthorizedError("unauthorized"), http.StatusUnauthorized, "UNAUTHORIZED"},
+		{exception.NewForbiddenError("forbidden"), http.StatusForbidden, "FORBIDDEN"},
+		{exception.NewConflictError("webhook is disabled"), http.StatusConflict, "CONFLICT"},
+		{helper.NewRequestBodyTooLargeError(10), http.StatusRequestEntityTooLarge, "REQUEST ENTITY TOO LARGE"},
+		{errors.New("boom"), http.StatusInternalServerError, "INTERNAL SERVER ERROR"},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		exception.ErrorHandler(recorder, request, c.err)
+
+		code, message := exception.ErrorStatus(request.Context(), c.err)
+		assert.Equal(t, c.code, code)
+		assert.Equal(t, c.code, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		var response map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &response)
+		assert.Equal(t, c.code, int(response["code"].(float64)))
+		assert.Equal(t, c.status, response["status"])
+		if c.code != http.StatusInternalServerError {
+			assert.Equal(t, message, response["data"])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/controller"
+	"project-restful-api/event"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEventServer(broker *event.Broker, config event.StreamConfig) *httptest.Server {
+	eventController := controller.NewEventController(broker, config)
+
+	router := httprouter.New()
+	router.GET("/api/events", eventController.Stream)
+	router.PanicHandler = exception.ErrorHandler
+	return httptest.NewServer(middleware.NewNegotiationMiddleware(router, helper.DefaultCodecs))
+}
+
+func openEventStream(t *testing.T, server *httptest.Server, query string, lastEventId string) (*http.Response, *bufio.Reader) {
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/api/events"+query, nil)
+	request.Header.Add("Accept", "text/event-stream")
+	if lastEventId != "" {
+		request.Header.Add("Last-Event-ID", lastEventId)
+	}
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	return response, bufio.NewReader(response.Body)
+}
+
+func readEventBlock(reader *bufio.Reader) (string, error) {
+	var block strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return block.String(), err
+		}
+		if line == "\n" {
+			return block.String(), nil
+		}
+		block.WriteString(line)
+	}
+}
+
+func TestEventStreamReplaysAndFilters(t *testing.T) {
+	broker := event.NewBroker(event.BrokerConfig{ReplaySize: 10, SubscriberBuffer: 10})
+	server := setupEventServer(broker, event.StreamConfig{Heartbeat: time.Minute, Retry: 2 * time.Second})
+	defer server.Close()
+
+	broker.Publish(domain.CategoryCreated, web.CategoryResponse{Id: 1, Name: "Gadget"})
+	broker.Publish(domain.CategoryUpdated, web.CategoryResponse{Id: 1, Name: "Book"})
+	broker.Publish(domain.CategoryCreated, web.CategoryResponse{Id: 2, Name: "Food"})
+
+	response, reader := openEventStream(t, server, "?types=category.updated,category.deleted", "1")
+	defer response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", response.Header.Get("Cache-Control"))
+
+	block, _ := readEventBlock(reader)
+	assert.Equal(t, "retry: 2000\n", block)
+	block, _ = readEventBlock(reader)
+	assert.Equal(t, "id: 2\nevent: category.updated\ndata: {\"id\":1,\"name\":\"Book\"}\n", block)
+
+	broker.Publish(domain.CategoryCreated, web.CategoryResponse{Id: 3, Name: "Toy"})
+	broker.Publish(domain.CategoryDeleted, web.CategoryResponse{Id: 2, Name: "Food"})
+
+	block, _ = readEventBlock(reader)
+	assert.Equal(t, "id: 5\nevent: category.deleted\ndata: {\"id\":2,\"name\":\"Food\"}\n", block)
+}
+
+func TestEventStreamHeartbeatAndShutdown(t *testing.T) {
+	broker := event.NewBroker(event.BrokerConfig{ReplaySize: 10, SubscriberBuffer: 10})
+	server := setupEventServer(broker, event.StreamConfig{Heartbeat: 20 * time.Millisecond, Retry: time.Second})
+	defer server.Close()
+
+	response, reader := openEventStream(t, server, "", "")
+	defer response.Body.Close()
+
+	readEventBlock(reader)
+	block, _ := readEventBlock(reader)
+	assert.Equal(t, ": heartbeat\n", block)
+
+	broker.Close()
+	_, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+
+	response, reader = openEventStream(t, server, "", "")
+	defer response.Body.Close()
+	body, _ := io.ReadAll(reader)
+	assert.Equal(t, "retry: 1000\n\n", string(body))
+}
+
+func TestEventStreamRejectsUnknownType(t *testing.T) {
+	broker := event.NewBroker(event.BrokerConfig{ReplaySize: 10, SubscriberBuffer: 10})
+	server := setupEventServer(broker, event.StreamConfig{Heartbeat: time.Minute})
+	defer server.Close()
+
+	response, reader := openEventStream(t, server, "?types=category.renamed", "")
+	defer response.Body.Close()
+	body, _ := io.ReadAll(reader)
+	assert.Equal(t, 400, response.StatusCode)
+	assert.Contains(t, string(body), "query parameter types must be one of category.created, category.updated, category.deleted")
+}
+
+func TestEventBrokerBoundsReplayAndDropsSlowSubscribers(t *testing.T) {
+	broker := event.NewBroker(event.BrokerConfig{ReplaySize: 2, SubscriberBuffer: 1})
+	for i := 1; i <= 3; i++ {
+		broker.Publish(domain.CategoryCreated, web.CategoryResponse{Id: i})
+	}
+
+	_, replay := broker.Subscribe(nil, 0)
+	assert.Empty(t, replay)
+
+	subscription, replay := broker.Subscribe(nil, 1)
+	assert.Len(t, replay, 2)
+	assert.Equal(t, uint64(2), replay[0].Id)
+
+	broker.Publish(domain.CategoryUpdated, web.CategoryResponse{Id: 1})
+	broker.Publish(domain.CategoryUpdated, web.CategoryResponse{Id: 1})
+	<-subscription.Events
+	_, open := <-subscription.Events
+	assert.False(t, open)
+	assert.True(t, subscription.Overflowed)
+}
+
+func TestEventStreamConfigKeepsMaxDurationPositive(t *testing.T) {
+	serverConfig := app.NewServerConfig()
+	assert.Equal(t, serverConfig.WriteTimeout-5*time.Second, app.NewEventStreamConfig(serverConfig).MaxDuration)
+
+	serverConfig.WriteTimeout = 4 * time.Second
+	assert.Equal(t, 2*time.Second, app.NewEventStreamConfig(serverConfig).MaxDuration)
+
+	serverConfig.WriteTimeout = 0
+	assert.Equal(t, time.Hour, app.NewEventStreamConfig(serverConfig).MaxDuration)
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/controller"
+	"project-restful-api/exception"
+	"project-restful-api/graphql"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCategoryService struct {
+	categories []web.CategoryResponse
+}
+
+func (service *memoryCategoryService) Create(ctx context.Context, request web.CategoryCreateRequest) web.CategoryResponse {
+	category := web.CategoryResponse{Id: len(service.categories) + 1, Name: request.Name}
+	service.categories = append(service.categories, category)
+	return category
+}
+
+func (service *memoryCategoryService) Update(ctx context.Context, request web.CategoryUpdateRequest) web.CategoryResponse {
+	for i := range service.categories {
+		if service.categories[i].Id == request.Id {
+			service.categories[i].Name = request.Name
+			return service.categories[i]
+		}
+	}
+	panic(exception.NewNotFoundError("category is not found"))
+}
+
+func (service *memoryCategoryService) Delete(ctx context.Context, categoryId int) {
+	service.FindById(ctx, categoryId)
+}
+
+func (service *memoryCategoryService) FindById(ctx context.Context, categoryId int) web.CategoryResponse {
+	for _, category := range service.categories {
+		if category.Id == categoryId {
+			return category
+		}
+	}
+	panic(exception.NewNotFoundError("category is not found"))
+}
+
+func (service *memoryCategoryService) FindAll(ctx context.Context) []web.CategoryResponse {
+	return append([]web.CategoryResponse{}, service.categories...)
+}
+
+func (service *memoryCategoryService) FindParents(ctx context.Context, categoryIds []int) map[int]web.CategoryResponse {
+	return map[int]web.CategoryResponse{}
+}
+
+func (service *memoryCategoryService) FindTodos(ctx context.Context, categoryIds []int) map[int][]web.TodoResponse {
+	return map[int][]web.TodoResponse{}
+}
+
+func executeGraphql(ctx context.Context, query string, variables map[string]interface{}) string {
+	service := &memoryCategoryService{categories: []web.CategoryResponse{{Id: 1, Name: "Gadget"}, {Id: 2, Name: "Food"}, {Id: 3, Name: "Game"}}}
+	schema := controller.NewGraphqlSchema(service)
+	response := schema.Execute(ctx, graphql.Request{Query: query, Variables: variables}, graphql.Limits{MaxDepth: 4, MaxComplexity: 50})
+	body, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+	return string(body)
+}
+
+func principalContext() context.Context {
+	return middleware.WithPrincipal(context.Background(), domain.Principal{Name: "default", Role: domain.RoleUser})
+}
+
+func TestGraphqlCategoryConnection(t *testing.T) {
+	query := `query Page($after: String) {
+		categories(first: 1, after: $after, filter: {name: "g"}) {
+			totalCount
+			edges { cursor node { id name } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+		}
+	}`
+
+	body := executeGraphql(principalContext(), query, nil)
+	assert.JSONEq(t, `{"data":{"categories":{
+		"totalCount": 2,
+		"edges": [{"cursor": "Y2F0ZWdvcnk6MQ==", "node": {"id": "1", "name": "Gadget"}}],
+		"pageInfo": {"hasNextPage": true, "hasPreviousPage": false, "endCursor": "Y2F0ZWdvcnk6MQ=="}
+	}}}`, body)
+
+	body = executeGraphql(principalContext(), query, map[string]interface{}{"after": "Y2F0ZWdvcnk6MQ=="})
+	assert.Contains(t, body, `"edges":[{"cursor":"Y2F0ZWdvcnk6Mw==","node":{"id":"3","name":"Game"}}]`)
+	assert.Contains(t, body, `"hasNextPage":false,"hasPreviousPage":true`)
+}
+
+func TestGraphqlMutationAndErrors(t *testing.T) {
+	body := executeGraphql(principalContext(), `mutation { created: createCategory(input: {name: "Book"}) { id name __typename } }`, nil)
+	assert.JSONEq(t, `{"data":{"created":{"id":"4","name":"Book","__typename":"Category"}}}`, body)
+
+	body = executeGraphql(principalContext(), `mutation {
+		deleteCategory(id: "1")
+		missing: updateCategory(id: "9", input: {name: "Toy"}) { id }
+	}`, nil)
+	assert.JSONEq(t, `{"data":null,"errors":[{"message":"category is not found","locations":[{"line":3,"column":3}],"path":["missing"],"extensions":{"code":"NOT_FOUND"}}]}`, body)
+}
+
+func TestGraphqlFieldAuthorization(t *testing.T) {
+	body := executeGraphql(context.Background(), `{ category(id: 1) { name } }`, nil)
+
+	assert.JSONEq(t, `{"data":{"category":null},"errors":[{"message":"authentication is required","locations":[{"line":1,"column":3}],"path":["category"],"extensions":{"code":"UNAUTHENTICATED"}}]}`, body)
+}
+
+func TestGraphqlValidationAndLimits(t *testing.T) {
+	body := executeGraphql(principalContext(), `{ category(id: 1) { name secret } }`, nil)
+	assert.Contains(t, body, `Cannot query field \"secret\" on type \"Category\".`)
+
+	body = executeGraphql(principalContext(), `{ categories { edges { node { id } } } `, nil)
+	assert.Contains(t, body, `"message":"Syntax Error: unexpected \u003cEOF\u003e"`)
+	assert.Contains(t, body, `GRAPHQL_PARSE_FAILED`)
+
+	body = executeGraphql(principalContext(), `{ categories(first: 20) { edges { node { id name } } } }`, nil)
+	assert.Contains(t, body, "Query complexity 81 exceeds the maximum allowed complexity of 50.")
+
+	body = executeGraphql(principalContext(), `{ categories(first: 1) { edges { node { ...Fields } } } } fragment Fields on Category { id }`, nil)
+	assert.Contains(t, body, `"data":{"categories":{"edges":[{"node":{"id":"1"}}]}}`)
+
+	body = executeGraphql(principalContext(), `query($first: Int!) { categories(first: $first) { totalCount } }`, map[string]interface{}{"first": "ten"})
+	assert.Contains(t, body, `Variable \"$first\" got invalid value`)
+}
+
+func TestGraphqlComplexityIgnoresNegativePageSize(t *testing.T) {
+	body := executeGraphql(principalContext(), `{
+		a: categories(first: -1000000) { totalCount }
+		b: categories(first: 10) { nodes { id name } }
+		c: categories(first: 10) { nodes { id name } }
+	}`, nil)
+
+	assert.Contains(t, body, "Query complexity 63 exceeds the maximum allowed complexity of 50.")
+	assert.NotContains(t, body, `"data":{`)
+}
+
+func TestGraphqlEndpoint(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/graphql", strings.NewReader(`{"query": "{ categories { totalCount } }"}`))
+	request.Header.Add("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 401, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/graphql", strings.NewReader(`{"query": "{ categories { totalCount "}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "GRAPHQL_PARSE_FAILED")
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveness(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestReadinessWhileNotServing(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/readyz", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 503, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+
+	var responseBody map[string]interface{}
+
+	err := json.Unmarshal(body, &responseBody)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, "SERVICE UNAVAILABLE", responseBody["status"])
+	checks := responseBody["data"].(map[string]interface{})["checks"].(map[string]interface{})
+	assert.Equal(t, "shutting down", checks["server"])
+}
+
+func TestAdminStatus(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/admin/status", nil)
+	request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+
+	var responseBody map[string]interface{}
+
+	err := json.Unmarshal(body, &responseBody)
+	if err != nil {
+		panic(err)
+	}
+
+	data := responseBody["data"].(map[string]interface{})
+	assert.Equal(t, "dev", data["version"])
+	assert.Equal(t, float64(20), data["database"].(map[string]interface{})["max_open_connections"])
+}
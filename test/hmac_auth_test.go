@@ -0,0 +1,107 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"project-restful-api/app"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/repository"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHmacRouter() http.Handler {
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	authMiddleware := middleware.NewAuthMiddleware(app.NewApiKeys(), repository.NewSessionRepository(), app.NewTwoFactorPolicy(), authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+
+	router := httprouter.New()
+	router.POST("/signed", authMiddleware.Authenticate(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		principal, _ := middleware.PrincipalFromContext(request.Context())
+		body, _ := io.ReadAll(request.Body)
+		writer.Write([]byte(principal.Name + ":" + string(body)))
+	}))
+	router.PanicHandler = exception.ErrorHandler
+	return router
+}
+
+func newSignedRequest(body string, signedBody string, timestamp time.Time, nonce string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/signed?b=2&a=1", strings.NewReader(body))
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	query := url.Values{"a": {"1"}, "b": {"2"}}
+	signature := helper.HmacSHA256Hex("RAHASIA_SIGNING_SECRET", []byte(helper.CanonicalRequest(http.MethodPost, "/signed", query, []byte(signedBody), unix, nonce)))
+
+	request.Header.Add("Authorization", "HMAC-SHA256 Credential=default, Signature="+signature)
+	request.Header.Add("X-Timestamp", unix)
+	request.Header.Add("X-Nonce", nonce)
+	return request
+}
+
+func TestHmacSignedRequestSuccess(t *testing.T) {
+	router := setupHmacRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, newSignedRequest(`{"name": "Gadget"}`, `{"name": "Gadget"}`, time.Now(), "nonce-1"))
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `default:{"name": "Gadget"}`, recorder.Body.String())
+}
+
+func TestHmacSignedRequestReplay(t *testing.T) {
+	router := setupHmacRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, newSignedRequest("{}", "{}", time.Now(), "nonce-1"))
+	assert.Equal(t, 200, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, newSignedRequest("{}", "{}", time.Now(), "nonce-1"))
+	assert.Equal(t, 401, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "nonce has already been used")
+}
+
+func TestHmacSignedRequestFailed(t *testing.T) {
+	router := setupHmacRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, newSignedRequest(`{"name": "Laptop"}`, `{"name": "Gadget"}`, time.Now(), "nonce-1"))
+	assert.Equal(t, 401, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "signature does not match")
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, newSignedRequest("{}", "{}", time.Now().Add(-10*time.Minute), "nonce-2"))
+	assert.Equal(t, 401, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "clock skew")
+}
+
+func TestHmacSignedUnversionedPath(t *testing.T) {
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	authMiddleware := middleware.NewAuthMiddleware(app.NewApiKeys(), repository.NewSessionRepository(), app.NewTwoFactorPolicy(), authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+	router := httprouter.New()
+	router.GET("/api/v1/categories", authMiddleware.Authenticate(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.Write([]byte(request.URL.Path))
+	}))
+	router.PanicHandler = exception.ErrorHandler
+	apiVersions := app.NewApiVersions()
+	handler := middleware.NewApiVersionMiddleware(router, apiVersions.Resources, apiVersions.Policies, apiVersions.Default)
+
+	unix := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := helper.HmacSHA256Hex("RAHASIA_SIGNING_SECRET", []byte(helper.CanonicalRequest(http.MethodGet, "/api/categories", url.Values{}, nil, unix, "nonce-unversioned")))
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("Authorization", "HMAC-SHA256 Credential=default, Signature="+signature)
+	request.Header.Add("X-Timestamp", unix)
+	request.Header.Add("X-Nonce", "nonce-unversioned")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "/api/v1/categories", recorder.Body.String())
+}
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJsonApiRouter() http.Handler {
+	relations := helper.Relations{
+		"parent": {
+			Type: "categories",
+			Load: func(ctx context.Context, ids []int) map[int]interface{} {
+				return map[int]interface{}{1: web.CategoryResponse{Id: 9, Name: "Electronic"}}
+			},
+		},
+	}
+	resource := middleware.Chain(
+		middleware.JsonApiResource("categories", relations),
+		middleware.ResponseShape(web.CategoryResponse{}, relations),
+	)
+
+	router := httprouter.New()
+	router.GET("/api/v1/categories", resource(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		helper.WriteToResponseBody(writer, negotiationFixture)
+	}))
+	router.GET("/api/v1/categories/:categoryId", resource(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		panic(exception.NewNotFoundError("category is not found"))
+	}))
+	router.POST("/api/v1/categories", resource(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		categoryCreateRequest := web.CategoryCreateRequest{}
+		helper.Bind(request, params, &categoryCreateRequest, nil)
+		helper.WriteToResponseBody(writer, web.WebResponse{Code: 200, Status: "OK", Data: web.CategoryResponse{Id: 3, Name: categoryCreateRequest.Name}})
+	}))
+	router.PanicHandler = exception.ErrorHandler
+	return middleware.NewNegotiationMiddleware(router, helper.DefaultCodecs)
+}
+
+func TestJsonApiCollection(t *testing.T) {
+	router := setupJsonApiRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/categories?page[size]=1&page[number]=2&include=parent", nil)
+	request.Header.Add("Accept", "application/vnd.api+json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/vnd.api+json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"data": [
+			{"type": "categories", "id": "2", "attributes": {"name": "Food, Drink"}, "relationships": {"parent": {"data": null}}, "links": {"self": "/api/v1/categories/2"}}
+		],
+		"links": {
+			"self": "/api/v1/categories?page[size]=1&page[number]=2&include=parent",
+			"first": "/api/v1/categories?include=parent&page%5Bnumber%5D=1&page%5Bsize%5D=1",
+			"last": "/api/v1/categories?include=parent&page%5Bnumber%5D=2&page%5Bsize%5D=1",
+			"prev": "/api/v1/categories?include=parent&page%5Bnumber%5D=1&page%5Bsize%5D=1"
+		},
+		"meta": {"total": 2},
+		"jsonapi": {"version": "1.1"}
+	}`, recorder.Body.String())
+}
+
+func TestJsonApiIncluded(t *testing.T) {
+	router := setupJsonApiRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/categories?page[size]=1&include=parent", nil)
+	request.Header.Add("Accept", "application/vnd.api+json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"relationships":{"parent":{"data":{"type":"categories","id":"9"}}}`)
+	assert.Contains(t, recorder.Body.String(), `"included":[{"type":"categories","id":"9","attributes":{"name":"Electronic"}}]`)
+	assert.Contains(t, recorder.Body.String(), `"next":"/api/v1/categories?include=parent&page%5Bnumber%5D=2&page%5Bsize%5D=1"`)
+}
+
+func TestJsonApiErrors(t *testing.T) {
+	router := setupJsonApiRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/categories/404", nil)
+	request.Header.Add("Accept", "application/vnd.api+json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "application/vnd.api+json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"errors":[{"status":"404","title":"NOT FOUND","detail":"category is not found"}],"jsonapi":{"version":"1.1"}}`, recorder.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/categories?page[size]=0", nil)
+	request.Header.Add("Accept", "application/vnd.api+json")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "query parameter page[size] must be a positive integer")
+}
+
+func TestJsonApiRequestBody(t *testing.T) {
+	router := setupJsonApiRouter()
+
+	requestBody := strings.NewReader(`{"data": {"type": "categories", "attributes": {"name": "Gadget"}}}`)
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/v1/categories", requestBody)
+	request.Header.Add("Content-Type", "application/vnd.api+json")
+	request.Header.Add("Accept", "application/vnd.api+json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"data":{"type":"categories","id":"3","attributes":{"name":"Gadget"},"links":{"self":"/api/v1/categories/3"}},"links":{"self":"/api/v1/categories"},"jsonapi":{"version":"1.1"}}`, recorder.Body.String())
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/metrics"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Add("X-API-Key", "SALAH")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/metrics", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header.Get("Content-Type"))
+
+	body, _ := io.ReadAll(response.Body)
+	metrics := string(body)
+
+	assert.Contains(t, metrics, "# TYPE http_requests_total counter")
+	assert.Contains(t, metrics, `http_requests_total{method="GET",route="/healthz",status="200"}`)
+	assert.Contains(t, metrics, `http_requests_total{method="GET",route="/api/v1/categories",status="401"}`)
+	assert.Contains(t, metrics, `http_request_duration_seconds_bucket{method="GET",route="/healthz",status="200",le="+Inf"}`)
+	assert.NotContains(t, metrics, `http_panics_total{type="exception.UnauthorizedError"}`)
+}
+
+func TestMetricsPanicsCountsOnlyUnexpectedPanics(t *testing.T) {
+	before := metrics.PanicsTotal.Value("exception.NotFoundError")
+	exception.ErrorHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), exception.NewNotFoundError("category is not found"))
+	assert.Equal(t, before, metrics.PanicsTotal.Value("exception.NotFoundError"))
+
+	before = metrics.PanicsTotal.Value("*errors.errorString")
+	recorder := httptest.NewRecorder()
+	exception.ErrorHandler(recorder, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("boom"))
+	assert.Equal(t, 500, recorder.Code)
+	assert.Equal(t, before+1, metrics.PanicsTotal.Value("*errors.errorString"))
+}
+
+func TestMetricsRegisterDBStatsTwice(t *testing.T) {
+	registry := metrics.NewRegistry()
+	first, _ := sql.Open("mysql", "root@tcp(localhost:3306)/first")
+	second, _ := sql.Open("mysql", "root@tcp(localhost:3306)/second")
+	defer first.Close()
+	defer second.Close()
+	second.SetMaxOpenConns(7)
+
+	metrics.RegisterDBStats(registry, first)
+	metrics.RegisterDBStats(registry, second)
+
+	var exposed strings.Builder
+	registry.Expose(&exposed)
+	assert.Equal(t, 1, strings.Count(exposed.String(), "# TYPE db_max_open_connections gauge"))
+	assert.Contains(t, exposed.String(), "db_max_open_connections 7\n")
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/helper"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var negotiationFixture = web.WebResponse{
+	Code:   200,
+	Status: "OK",
+	Data: []web.CategoryResponse{
+		{Id: 1, Name: "Gadget"},
+		{Id: 2, Name: "Food, Drink"},
+	},
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	codec, ok := helper.DefaultCodecs.Negotiate("text/html;q=0.9, application/xml;q=0.8")
+	assert.True(t, ok)
+	assert.Equal(t, "xml", codec.Format())
+
+	codec, ok = helper.DefaultCodecs.Negotiate("application/yaml;q=0.5, application/msgpack")
+	assert.True(t, ok)
+	assert.Equal(t, "msgpack", codec.Format())
+
+	codec, ok = helper.DefaultCodecs.Negotiate("text/*")
+	assert.True(t, ok)
+	assert.Equal(t, "xml", codec.Format())
+
+	_, ok = helper.DefaultCodecs.Negotiate("image/png")
+	assert.False(t, ok)
+}
+
+func TestEncodeFormats(t *testing.T) {
+	var output bytes.Buffer
+	codec, _ := helper.DefaultCodecs.ForFormat("xml")
+	assert.Nil(t, codec.Encode(&output, negotiationFixture))
+	assert.Contains(t, output.String(), "<response><code>200</code><status>OK</status><data><item><id>1</id><name>Gadget</name></item>")
+
+	output.Reset()
+	codec, _ = helper.DefaultCodecs.ForFormat("yaml")
+	assert.Nil(t, codec.Encode(&output, negotiationFixture))
+	assert.Equal(t, "code: 200\nstatus: OK\ndata:\n  - id: 1\n    name: Gadget\n  - id: 2\n    name: Food, Drink\n", output.String())
+
+	output.Reset()
+	codec, _ = helper.DefaultCodecs.ForFormat("csv")
+	assert.Nil(t, codec.Encode(&output, negotiationFixture))
+	assert.Equal(t, "id,name\n1,Gadget\n2,\"Food, Drink\"\n", output.String())
+
+	output.Reset()
+	assert.Nil(t, codec.Encode(&output, []web.CategoryResponse{{Id: -1, Name: "=cmd|' /C calc'!A0"}, {Id: 2, Name: "@SUM(A1)"}}))
+	assert.Equal(t, "id,name\n-1,'=cmd|' /C calc'!A0\n2,'@SUM(A1)\n", output.String())
+}
+
+func TestDecodeFormats(t *testing.T) {
+	documents := map[string]string{
+		"application/xml":  "<request><id>7</id><name>Gadget</name></request>",
+		"application/yaml": "# category\nid: 7\nname: \"Gadget\"\n",
+		"text/csv":         "id,name\n7,Gadget\n",
+	}
+	for contentType, document := range documents {
+		codec, ok := helper.DefaultCodecs.ForContentType(contentType)
+		assert.True(t, ok)
+
+		request := web.CategoryUpdateRequest{}
+		assert.Nil(t, codec.Decode(strings.NewReader(document), &request), contentType)
+		assert.Equal(t, web.CategoryUpdateRequest{Id: 7, Name: "Gadget"}, request, contentType)
+	}
+
+	var encoded bytes.Buffer
+	codec, _ := helper.DefaultCodecs.ForFormat("msgpack")
+	assert.Nil(t, codec.Encode(&encoded, web.CategoryUpdateRequest{Id: 300, Name: "Gadget"}))
+	request := web.CategoryUpdateRequest{}
+	assert.Nil(t, codec.Decode(&encoded, &request))
+	assert.Equal(t, web.CategoryUpdateRequest{Id: 300, Name: "Gadget"}, request)
+}
+
+func TestResponseFormatNegotiation(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/unknown", nil)
+	request.Header.Add("Accept", "application/xml")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "<status>NOT FOUND</status>")
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/readyz?format=yaml", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 503, recorder.Code)
+	assert.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "server: shutting down")
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	request.Header.Add("Accept", "image/png")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 406, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "NOT ACCEPTABLE")
+}
+
+func TestYamlRoundTrip(t *testing.T) {
+	var output bytes.Buffer
+	codec, _ := helper.DefaultCodecs.ForFormat("yaml")
+	assert.Nil(t, codec.Encode(&output, negotiationFixture))
+
+	var decoded web.WebResponse
+	assert.Nil(t, codec.Decode(&output, &decoded))
+	assert.Equal(t, 200, decoded.Code)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": json.Number("1"), "name": "Gadget"},
+		map[string]interface{}{"id": json.Number("2"), "name": "Food, Drink"},
+	}, decoded.Data)
+}
+
+func TestDecodeMessagePackRejectsOversizedLengths(t *testing.T) {
+	codec, _ := helper.DefaultCodecs.ForFormat("msgpack")
+	documents := map[string][]byte{
+		"string": {0x81, 0xa4, 'n', 'a', 'm', 'e', 0xdb, 0xff, 0xff, 0xff, 0xff},
+		"binary": {0x81, 0xa4, 'n', 'a', 'm', 'e', 0xc6, 0xff, 0xff, 0xff, 0xff},
+		"array":  {0xdd, 0xff, 0xff, 0xff, 0xff},
+		"map":    {0xdf, 0xff, 0xff, 0xff, 0xff},
+	}
+	for name, document := range documents {
+		request := web.CategoryUpdateRequest{}
+		err := codec.Decode(bytes.NewReader(document), &request)
+		assert.ErrorContains(t, err, "exceeds the document", name)
+	}
+}
+
+func TestDecodeYamlScalars(t *testing.T) {
+	codec, _ := helper.DefaultCodecs.ForFormat("yaml")
+	documents := map[string]string{
+		"name: |\n  line one\n":      "line one\n",
+		"name: 'it''s'\n":            "it's",
+		"name: \"tab\\there\"\n":     "tab\there",
+		"{\"id\": 7, \"name\": x}\n": "x",
+	}
+	for document, name := range documents {
+		request := web.CategoryUpdateRequest{}
+		assert.Nil(t, codec.Decode(strings.NewReader(document), &request), document)
+		assert.Equal(t, name, request.Name, document)
+	}
+
+	request := web.CategoryUpdateRequest{}
+	assert.ErrorContains(t, codec.Decode(strings.NewReader("name: {a: 1}\n"), &request), "expected a scalar, got an object")
+	assert.Error(t, codec.Decode(strings.NewReader("name: \"unterminated\n"), &request))
+	assert.Error(t, codec.Decode(strings.NewReader("name: \"a\\/b\"\n"), &request))
+	assert.Equal(t, "", request.Name)
+
+	var output bytes.Buffer
+	assert.Nil(t, codec.Encode(&output, web.CategoryResponse{Id: 1, Name: "nul\x00byte"}))
+	assert.Equal(t, "id: 1\nname: \"nul\\0byte\"\n", output.String())
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"project-restful-api/helper"
+	"project-restful-api/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowQuerier struct {
+	delay time.Duration
+}
+
+func (querier slowQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	time.Sleep(querier.delay)
+	return driver.RowsAffected(1), nil
+}
+
+func (querier slowQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	time.Sleep(querier.delay)
+	return nil, nil
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t, "select id, name from category where id = ?", repository.NormalizeQuery("SELECT id, name\n  FROM category WHERE id = 42"))
+	assert.Equal(t, "select * from category where name = ? and id in (?+)", repository.NormalizeQuery("select * from category where name = 'it''s' and id in (?, ?, ?)"))
+}
+
+func TestQueryLogSlowStatements(t *testing.T) {
+	var output bytes.Buffer
+	helper.Logger.SetOutput(&output)
+	defer helper.Logger.SetOutput(os.Stdout)
+
+	queryLog := repository.NewQueryLog(repository.QueryLogConfig{SlowThreshold: 20 * time.Millisecond, MaxFingerprint: 10})
+
+	fast := queryLog.Wrap(slowQuerier{}, "fast")
+	fast.ExecContext(context.Background(), "update category set name = ? where id = ?", "secret name", 1)
+	assert.Equal(t, "", output.String())
+
+	slow := queryLog.Wrap(slowQuerier{delay: 30 * time.Millisecond}, "slow")
+	slow.ExecContext(context.Background(), "delete from category where id = ?", 7)
+	assert.Contains(t, output.String(), `"message":"slow sql statement"`)
+	assert.Contains(t, output.String(), `"args":["[redacted int]"]`)
+	assert.Contains(t, output.String(), `"rows_affected":1`)
+
+	slower := queryLog.Wrap(slowQuerier{delay: 60 * time.Millisecond}, "slower")
+	slower.ExecContext(context.Background(), "update category set name = ? where id = ?", "secret name", 2)
+	assert.NotContains(t, output.String(), "secret name")
+
+	stats := queryLog.Slowest(1)
+	assert.Equal(t, 1, len(stats))
+	assert.Equal(t, "update category set name = ? where id = ?", stats[0].Query)
+	assert.Equal(t, 2, stats[0].Count)
+	assert.Equal(t, 1, stats[0].SlowCount)
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRateLimitRouter(store middleware.RateLimitStore, config middleware.RateLimitConfig) http.Handler {
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(store, helper.ParseTrustedProxies("10.0.0.0/8"))
+
+	router := httprouter.New()
+	router.GET("/limited", rateLimitMiddleware.Limit("test", config)(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	router.PanicHandler = exception.ErrorHandler
+	return router
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := middleware.NewMemoryRateLimitStore().(*middleware.MemoryRateLimitStore)
+	store.Now = func() time.Time { return now }
+	router := setupRateLimitRouter(store, middleware.RateLimitConfig{Limit: 2, Period: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil))
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil))
+	assert.Equal(t, 429, recorder.Code)
+	assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", recorder.Header().Get("Retry-After"))
+
+	now = now.Add(30 * time.Second)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil))
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestRateLimitPerClientIP(t *testing.T) {
+	router := setupRateLimitRouter(middleware.NewMemoryRateLimitStore(), middleware.RateLimitConfig{Limit: 1, Period: time.Minute})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "203.0.113.1, 10.0.0.2")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "203.0.113.2")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil)
+	request.RemoteAddr = "203.0.113.9:1234"
+	request.Header.Set("X-Forwarded-For", "203.0.113.1")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/limited", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "203.0.113.1")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 429, recorder.Code)
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRequestBodyRouter() http.Handler {
+	router := httprouter.New()
+	router.POST("/lenient", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		categoryCreateRequest := web.CategoryCreateRequest{}
+		helper.ReadFromRequestBody(request, &categoryCreateRequest)
+		writer.Write([]byte(categoryCreateRequest.Name))
+	})
+	router.POST("/strict", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		categoryCreateRequest := web.CategoryCreateRequest{}
+		helper.ReadFromRequestBodyStrict(request, &categoryCreateRequest)
+		writer.Write([]byte(categoryCreateRequest.Name))
+	})
+	router.PanicHandler = exception.ErrorHandler
+	return middleware.NewBodyLimitMiddleware(router, 32)
+}
+
+func postJson(router http.Handler, path string, contentType string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000"+path, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Add("Content-Type", contentType)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRequestBodyDecoded(t *testing.T) {
+	router := setupRequestBodyRouter()
+
+	recorder := postJson(router, "/lenient", "application/json; charset=utf-8", `{"name": "Gadget", "extra": 1}`)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "Gadget", recorder.Body.String())
+
+	recorder = postJson(router, "/strict", "application/json", `{"name": "Gadget"}`)
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestRequestBodyRejected(t *testing.T) {
+	router := setupRequestBodyRouter()
+
+	recorder := postJson(router, "/lenient", "text/plain", `{"name": "Gadget"}`)
+	assert.Equal(t, 415, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "UNSUPPORTED MEDIA TYPE")
+
+	recorder = postJson(router, "/lenient", "application/json", `{"name": "`+strings.Repeat("a", 64)+`"}`)
+	assert.Equal(t, 413, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "larger than 32 bytes")
+
+	recorder = postJson(router, "/strict", "application/json", `{"name": "Gadget", "extra": 1}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `unknown field \"extra\"`)
+
+	recorder = postJson(router, "/lenient", "application/json", `{"name": "Gadget"} {}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "single JSON value")
+
+	recorder = postJson(router, "/lenient", "application/json", `{"name": 12}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `field \"name\" must be string at offset 11`)
+
+	recorder = postJson(router, "/lenient", "application/json", `{"name" "Gadget"}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "malformed JSON at offset 9")
+}
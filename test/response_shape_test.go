@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/web"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupResponseShapeRouter(batches *[][]int) http.Handler {
+	relations := helper.Relations{
+		"parent": {
+			Type: "categories",
+			Load: func(ctx context.Context, ids []int) map[int]interface{} {
+				*batches = append(*batches, ids)
+				parents := map[int]interface{}{}
+				for _, id := range ids {
+					parents[id] = web.CategoryResponse{Id: id * 10, Name: "Parent"}
+				}
+				return parents
+			},
+		},
+	}
+
+	router := httprouter.New()
+	router.GET("/categories", middleware.ResponseShape(web.CategoryResponse{}, relations)(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		helper.WriteToResponseBody(writer, negotiationFixture)
+	}))
+	router.PanicHandler = exception.ErrorHandler
+	return middleware.NewNegotiationMiddleware(router, helper.DefaultCodecs)
+}
+
+func TestResponseShapeFields(t *testing.T) {
+	var batches [][]int
+	router := setupResponseShapeRouter(&batches)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/categories?fields=name", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"code":200,"status":"OK","data":[{"name":"Gadget"},{"name":"Food, Drink"}]}`, recorder.Body.String())
+	assert.Empty(t, batches)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/categories?fields=id&format=csv", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "id\n1\n2\n", recorder.Body.String())
+}
+
+func TestResponseShapeIncludeLoadsInOneBatch(t *testing.T) {
+	var batches [][]int
+	router := setupResponseShapeRouter(&batches)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/categories?include=parent&fields=id", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"code":200,"status":"OK","data":[{"id":1,"parent":{"id":10,"name":"Parent"}},{"id":2,"parent":{"id":20,"name":"Parent"}}]}`, recorder.Body.String())
+	assert.Equal(t, [][]int{{1, 2}}, batches)
+}
+
+func TestResponseShapeUnknownField(t *testing.T) {
+	var batches [][]int
+	router := setupResponseShapeRouter(&batches)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/categories?fields=id,secret", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "query parameter fields contains unknown field secret")
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/categories?include=todos", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "contains unknown relation todos, must be one of [parent]")
+}
+
+func TestCategoryIncludeParentAndTodos(t *testing.T) {
+	db := setupTestDB()
+	truncateCategory(db)
+	router := setupRouter(db)
+
+	db.Exec("insert into category(id, name) values (1, 'Electronics'), (2, 'Gadget')")
+	db.Exec("update category set parent_id = 1 where id = 2")
+	db.Exec("insert into todo(category_id, title, done) values (2, 'Charge phone', true), (2, 'Buy cable', false)")
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories/2?include=todos,parent", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"code":200,"status":"OK","data":{"id":2,"name":"Gadget","todos":[{"id":1,"title":"Charge phone","done":true},{"id":2,"title":"Buy cable","done":false}],"parent":{"id":1,"name":"Electronics"}}}`, recorder.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories?include=parent,todos&fields=id", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"code":200,"status":"OK","data":[{"id":1,"parent":null,"todos":[]},{"id":2,"parent":{"id":1,"name":"Electronics"},"todos":[{"id":1,"title":"Charge phone","done":true},{"id":2,"title":"Buy cable","done":false}]}]}`, recorder.Body.String())
+}
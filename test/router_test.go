@@ -0,0 +1,71 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readWebResponse(t *testing.T, response *http.Response) map[string]interface{} {
+	body, _ := io.ReadAll(response.Body)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(body, &responseBody)
+	assert.Nil(t, err, string(body))
+	return responseBody
+}
+
+func TestUnknownRouteNotFound(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/unknown", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 404, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	responseBody := readWebResponse(t, response)
+	assert.Equal(t, float64(404), responseBody["code"])
+	assert.Equal(t, "NOT FOUND", responseBody["status"])
+}
+
+func TestWrongMethodNotAllowed(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodPatch, "http://localhost:3000/api/categories/1", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 405, response.StatusCode)
+	assert.Equal(t, "DELETE, GET, OPTIONS, PUT", response.Header.Get("Allow"))
+
+	responseBody := readWebResponse(t, response)
+	assert.Equal(t, float64(405), responseBody["code"])
+	assert.Equal(t, "METHOD NOT ALLOWED", responseBody["status"])
+}
+
+func TestAutomaticOptions(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodOptions, "http://localhost:3000/api/categories", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "GET, OPTIONS, POST", response.Header.Get("Allow"))
+
+	responseBody := readWebResponse(t, response)
+	assert.Equal(t, []interface{}{"GET", "OPTIONS", "POST"}, responseBody["data"])
+}
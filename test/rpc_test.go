@@ -0,0 +1,84 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/controller"
+	"project-restful-api/exception"
+	"project-restful-api/model/web"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRpcRouter() (http.Handler, *memoryCategoryService) {
+	service := &memoryCategoryService{categories: []web.CategoryResponse{{Id: 1, Name: "Gadget"}}}
+	rpcController := controller.NewRpcController(service, validator.New())
+
+	router := httprouter.New()
+	router.POST("/rpc", rpcController.Call)
+	router.PanicHandler = exception.ErrorHandler
+	return router, service
+}
+
+func callRpc(router http.Handler, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/rpc", strings.NewReader(body))
+	request.Header.Add("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRpcCall(t *testing.T) {
+	router, _ := setupRpcRouter()
+
+	recorder := callRpc(router, `{"jsonrpc": "2.0", "method": "category.get", "params": {"id": 1}, "id": 7}`)
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","result":{"id":1,"name":"Gadget"},"id":7}`, recorder.Body.String())
+
+	recorder = callRpc(router, `{"jsonrpc": "2.0", "method": "category.get", "params": [9], "id": "a"}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32004,"message":"Not found","data":"category is not found"},"id":"a"}`, recorder.Body.String())
+
+	recorder = callRpc(router, `{"jsonrpc": "2.0", "method": "category.create", "params": {"name": ""}, "id": 1}`)
+	assert.Contains(t, recorder.Body.String(), `"code":-32602`)
+	assert.Contains(t, recorder.Body.String(), `Field validation for 'Name' failed on the 'required' tag`)
+
+	recorder = callRpc(router, `{"jsonrpc": "2.0", "method": "category.get", "params": {"id": 1, "name": "x"}, "id": 1}`)
+	assert.Contains(t, recorder.Body.String(), `"code":-32602`)
+
+	recorder = callRpc(router, `{"jsonrpc": "2.0", "method": "category.get", "params": {"id": 1}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`, recorder.Body.String())
+}
+
+func TestRpcBatch(t *testing.T) {
+	router, service := setupRpcRouter()
+
+	recorder := callRpc(router, `[
+		{"jsonrpc": "2.0", "method": "category.create", "params": ["Food"]},
+		{"jsonrpc": "2.0", "method": "category.update", "params": {"id": 1, "name": "Book"}, "id": 1},
+		{"jsonrpc": "2.0", "method": "category.drop", "id": 2},
+		{"jsonrpc": "1.0", "method": "category.list", "id": 3},
+		42,
+		{"jsonrpc": "2.0", "method": "category.list", "id": 4}
+	]`)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `[
+		{"jsonrpc":"2.0","result":{"id":1,"name":"Book"},"id":1},
+		{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found","data":"category.drop"},"id":2},
+		{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"jsonrpc must be exactly \"2.0\""},"id":3},
+		{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"request must be an object"},"id":null},
+		{"jsonrpc":"2.0","result":[{"id":1,"name":"Book"},{"id":2,"name":"Food"}],"id":4}
+	]`, recorder.Body.String())
+	assert.Len(t, service.categories, 2)
+
+	recorder = callRpc(router, `[{"jsonrpc": "2.0", "method": "category.delete", "params": [1]}]`)
+	assert.Equal(t, 204, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+
+	recorder = callRpc(router, `[]`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"batch must not be empty"},"id":null}`, recorder.Body.String())
+}
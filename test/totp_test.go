@@ -0,0 +1,189 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/exception"
+	"project-restful-api/helper"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTotpCodeMatchesRfc6238(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	code, err := helper.TotpCode(secret, time.Unix(59, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = helper.TotpCode(secret, time.Unix(1111111109, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "081804", code)
+
+	assert.True(t, helper.VerifyTotp(secret, "081804", time.Unix(1111111109+30, 0)))
+	assert.False(t, helper.VerifyTotp(secret, "081804", time.Unix(1111111109+90, 0)))
+}
+
+func TestTotpProvisioningUri(t *testing.T) {
+	uri := helper.TotpProvisioningUri("Todolist", "budi", "GEZDGNBVGY3TQOJQ")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Todolist:budi?"))
+	assert.Contains(t, uri, "secret=GEZDGNBVGY3TQOJQ")
+	assert.Contains(t, uri, "issuer=Todolist")
+}
+
+func TestTwoFactorRequiredForAdminSession(t *testing.T) {
+	sessionRepository := repository.NewSessionRepository()
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	authMiddleware := middleware.NewAuthMiddleware(app.NewApiKeys(), sessionRepository, app.NewTwoFactorPolicy(), authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+
+	router := httprouter.New()
+	router.GET("/admin", middleware.Chain(authMiddleware.Authenticate, authMiddleware.RequireRole(domain.RoleAdmin))(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	router.PanicHandler = exception.ErrorHandler
+
+	sessionRepository.Save(domain.Session{Token: "unverified", UserId: 1, Username: "budi", Role: domain.RoleAdmin, ExpiresAt: time.Now().Add(time.Hour)})
+	sessionRepository.Save(domain.Session{Token: "verified", UserId: 1, Username: "budi", Role: domain.RoleAdmin, TwoFactorVerified: true, ExpiresAt: time.Now().Add(time.Hour)})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/admin", nil)
+	request.Header.Add("Authorization", "Bearer unverified")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 403, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/admin", nil)
+	request.Header.Add("Authorization", "Bearer verified")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/admin", nil)
+	request.Header.Add("Authorization", "Bearer expired")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 401, recorder.Code)
+}
+
+func TestTwoFactorPolicyAppliesToApiKeys(t *testing.T) {
+	apiKeys := append(app.NewApiKeys(), domain.ApiKey{Key: "RAHASIA_OPERATOR", Name: "operator", Role: domain.RoleAdmin})
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	authMiddleware := middleware.NewAuthMiddleware(apiKeys, repository.NewSessionRepository(), app.NewTwoFactorPolicy(), authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+
+	router := httprouter.New()
+	router.GET("/admin", middleware.Chain(authMiddleware.Authenticate, authMiddleware.RequireRole(domain.RoleAdmin))(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	router.PanicHandler = exception.ErrorHandler
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/admin", nil)
+	request.Header.Add("X-API-Key", "RAHASIA_OPERATOR")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 403, recorder.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/admin", nil)
+	request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func callAuthApi(router http.Handler, path string, body string, authorization string) (int, map[string]interface{}) {
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000"+path, strings.NewReader(body))
+	request.Header.Add("Content-Type", "application/json")
+	if authorization != "" {
+		request.Header.Add("Authorization", authorization)
+	} else {
+		request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+	return recorder.Code, responseBody
+}
+
+func TestLoginLockoutAfterWrongPasswords(t *testing.T) {
+	db := setupTestDB()
+	db.Exec("DELETE FROM user")
+	router := setupRouter(db)
+
+	code, _ := callAuthApi(router, "/api/admin/users", `{"username": "budi", "password": "rahasia123", "role": "user"}`, "")
+	assert.Equal(t, 200, code)
+
+	for i := 0; i < 5; i++ {
+		code, _ = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "salah12345"}`, "")
+		assert.Equal(t, 401, code)
+	}
+	code, _ = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123"}`, "")
+	assert.Equal(t, 429, code)
+}
+
+func TestLoginRejectsReusedTotpCode(t *testing.T) {
+	db := setupTestDB()
+	db.Exec("DELETE FROM user")
+	router := setupRouter(db)
+
+	callAuthApi(router, "/api/admin/users", `{"username": "budi", "password": "rahasia123", "role": "user"}`, "")
+	_, response := callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123"}`, "")
+	bearer := "Bearer " + response["data"].(map[string]interface{})["token"].(string)
+
+	_, response = callAuthApi(router, "/api/auth/totp/enroll", "", bearer)
+	secret := response["data"].(map[string]interface{})["secret"].(string)
+	totpCode, _ := helper.TotpCode(secret, time.Now())
+	code, _ := callAuthApi(router, "/api/auth/totp/activate", `{"code": "`+totpCode+`"}`, bearer)
+	assert.Equal(t, 200, code)
+
+	code, response = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123", "totp_code": "`+totpCode+`"}`, "")
+	assert.Equal(t, 401, code)
+	assert.Equal(t, "totp code is invalid", response["data"])
+
+	totpCode, _ = helper.TotpCode(secret, time.Now().Add(helper.TotpPeriod))
+	code, _ = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123", "totp_code": "`+totpCode+`"}`, "")
+	assert.Equal(t, 200, code)
+
+	code, _ = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123", "totp_code": "`+totpCode+`"}`, "")
+	assert.Equal(t, 401, code)
+}
+
+func TestLoginWithRecoveryCodeOnce(t *testing.T) {
+	db := setupTestDB()
+	db.Exec("DELETE FROM user")
+	router := setupRouter(db)
+
+	callAuthApi(router, "/api/admin/users", `{"username": "budi", "password": "rahasia123", "role": "user"}`, "")
+	_, response := callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123"}`, "")
+	bearer := "Bearer " + response["data"].(map[string]interface{})["token"].(string)
+
+	_, response = callAuthApi(router, "/api/auth/totp/enroll", "", bearer)
+	secret := response["data"].(map[string]interface{})["secret"].(string)
+	recoveryCodes := response["data"].(map[string]interface{})["recovery_codes"].([]interface{})
+	assert.Len(t, recoveryCodes, 10)
+	assert.Regexp(t, `^[0-9a-f]{5}(-[0-9a-f]{5}){3}$`, recoveryCodes[0])
+	totpCode, _ := helper.TotpCode(secret, time.Now())
+	callAuthApi(router, "/api/auth/totp/activate", `{"code": "`+totpCode+`"}`, bearer)
+
+	var stored string
+	db.QueryRow("SELECT recovery_codes FROM user WHERE username = 'budi'").Scan(&stored)
+	assert.True(t, strings.HasPrefix(stored, "$2a$"))
+	assert.NotContains(t, stored, recoveryCodes[0].(string))
+
+	recoveryCode := strings.ToUpper(recoveryCodes[0].(string))
+	code, _ := callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123", "recovery_code": "`+recoveryCode+`"}`, "")
+	assert.Equal(t, 200, code)
+
+	code, response = callAuthApi(router, "/api/auth/login", `{"username": "budi", "password": "rahasia123", "recovery_code": "`+recoveryCode+`"}`, "")
+	assert.Equal(t, 401, code)
+	assert.Equal(t, "recovery code is invalid", response["data"])
+}
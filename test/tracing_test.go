@@ -0,0 +1,108 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/middleware"
+	"project-restful-api/tracing"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	spanContext, ok := tracing.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceId.String())
+	assert.Equal(t, "00f067aa0ba902b7", spanContext.SpanId.String())
+	assert.True(t, spanContext.Sampled)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", spanContext.Traceparent())
+
+	_, ok = tracing.ParseTraceparent("00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	assert.False(t, ok)
+	_, ok = tracing.ParseTraceparent("ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.False(t, ok)
+}
+
+func TestTracingExportsToOtlpCollector(t *testing.T) {
+	var mutex sync.Mutex
+	var payloads []map[string]interface{}
+	collector := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/v1/traces", request.URL.Path)
+		var payload map[string]interface{}
+		json.NewDecoder(request.Body).Decode(&payload)
+		mutex.Lock()
+		payloads = append(payloads, payload)
+		mutex.Unlock()
+	}))
+	defer collector.Close()
+
+	tracer := tracing.NewTracer(tracing.NewOtlpHttpExporter(collector.URL, "todolist-test"))
+	handler := middleware.NewTracingMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, span := tracer.Start(request.Context(), "CategoryService.FindAll", tracing.SpanKindInternal)
+		span.End()
+	}), tracer)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	assert.Nil(t, tracer.Shutdown(context.Background()))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 1, len(payloads))
+
+	resourceSpans := payloads[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	assert.Equal(t, 2, len(spans))
+
+	child := spans[0].(map[string]interface{})
+	server := spans[1].(map[string]interface{})
+	assert.Equal(t, "CategoryService.FindAll", child["name"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", child["traceId"])
+	assert.Equal(t, server["spanId"], child["parentSpanId"])
+	assert.Equal(t, "00f067aa0ba902b7", server["parentSpanId"])
+	assert.Equal(t, float64(tracing.SpanKindServer), server["kind"])
+}
+
+type memorySpanExporter struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (exporter *memorySpanExporter) Export(ctx context.Context, spans []*tracing.Span) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	for _, span := range spans {
+		exporter.names = append(exporter.names, span.Name)
+	}
+	return nil
+}
+
+func (exporter *memorySpanExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func TestTracingStartUsesInjectedTracer(t *testing.T) {
+	exporter := &memorySpanExporter{}
+	tracer := tracing.NewTracer(exporter)
+	assert.NotSame(t, tracer, tracing.Default())
+
+	handler := middleware.NewTracingMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Same(t, tracer, tracing.FromContext(request.Context()))
+		_, span := tracing.Start(request.Context(), "CategoryService.FindAll")
+		span.End()
+	}), tracer)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/categories", nil))
+
+	assert.Nil(t, tracer.Shutdown(context.Background()))
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	assert.Equal(t, []string{"CategoryService.FindAll", "GET"}, exporter.names)
+	assert.Same(t, tracing.Default(), tracing.FromContext(context.Background()))
+}
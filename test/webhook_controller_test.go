@@ -0,0 +1,132 @@
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/repository"
+	"project-restful-api/service"
+	"project-restful-api/webhook"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func truncateWebhook(db *sql.DB) {
+	db.Exec("DELETE FROM webhook")
+}
+
+func setupWebhookDispatcher(db *sql.DB, config webhook.Config) *webhook.Dispatcher {
+	config.AllowPrivateTargets = true
+	queryLog := repository.NewQueryLog(app.NewQueryLogConfig())
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(queryLog), repository.NewWebhookDeliveryRepository(queryLog), db, validator.New(), config)
+	return webhook.NewDispatcher(webhookService, nil, config)
+}
+
+func callWebhookApi(router http.Handler, method string, path string, body string) (int, map[string]interface{}) {
+	request := httptest.NewRequest(method, "http://localhost:3000"+path, strings.NewReader(body))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA_ADMIN")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	responseBody, _ := io.ReadAll(recorder.Result().Body)
+	var responseData map[string]interface{}
+	json.Unmarshal(responseBody, &responseData)
+	return recorder.Result().StatusCode, responseData
+}
+
+func createTestWebhook(t *testing.T, router http.Handler, url string) string {
+	code, response := callWebhookApi(router, http.MethodPost, "/api/webhooks", `{"url": "`+url+`", "secret": "`+webhookTestSecret+`", "event_types": ["category.created"]}`)
+	assert.Equal(t, 200, code)
+	return strconv.Itoa(int(response["data"].(map[string]interface{})["id"].(float64)))
+}
+
+func TestWebhookDeliversCategoryEvent(t *testing.T) {
+	db := setupTestDB()
+	truncateCategory(db)
+	truncateWebhook(db)
+	router := setupRouter(db)
+
+	received := make(chan []byte, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		assert.Nil(t, webhook.Verify(webhookTestSecret, request.Header.Get(webhook.HeaderTimestamp), request.Header.Get(webhook.HeaderSignature), body, time.Now()))
+		received <- body
+	}))
+	defer receiver.Close()
+
+	webhookId := createTestWebhook(t, router, receiver.URL)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/categories", strings.NewReader(`{"name": "Gadget"}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.Equal(t, 1, setupWebhookDispatcher(db, app.NewWebhookConfig()).DispatchOnce())
+
+	var payload map[string]interface{}
+	json.Unmarshal(<-received, &payload)
+	assert.Equal(t, "category.created", payload["type"])
+	assert.Equal(t, "Gadget", payload["data"].(map[string]interface{})["name"])
+
+	code, response := callWebhookApi(router, http.MethodGet, "/api/webhooks/"+webhookId+"/deliveries", "")
+	assert.Equal(t, 200, code)
+	deliveries := response["data"].([]interface{})
+	assert.Len(t, deliveries, 1)
+	delivery := deliveries[0].(map[string]interface{})
+	assert.Equal(t, "delivered", delivery["status"])
+	assert.Equal(t, 200, int(delivery["last_status_code"].(float64)))
+	assert.Len(t, delivery["attempts"], 1)
+
+	deliveryId := strconv.Itoa(int(delivery["id"].(float64)))
+	code, response = callWebhookApi(router, http.MethodPost, "/api/webhooks/"+webhookId+"/deliveries/"+deliveryId+"/redeliver", "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "pending", response["data"].(map[string]interface{})["status"])
+	assert.Equal(t, payload["id"], response["data"].(map[string]interface{})["event_id"])
+}
+
+func TestWebhookDisabledAfterRepeatedFailures(t *testing.T) {
+	db := setupTestDB()
+	truncateCategory(db)
+	truncateWebhook(db)
+	router := setupRouter(db)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	webhookId := createTestWebhook(t, router, receiver.URL)
+	for _, name := range []string{"Gadget", "Food"} {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/categories", strings.NewReader(`{"name": "`+name+`"}`))
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("X-API-Key", "RAHASIA")
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	config := app.NewWebhookConfig()
+	config.DisableAfter = 2
+	assert.Equal(t, 2, setupWebhookDispatcher(db, config).DispatchOnce())
+
+	code, response := callWebhookApi(router, http.MethodGet, "/api/webhooks/"+webhookId, "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, false, response["data"].(map[string]interface{})["active"])
+	assert.Equal(t, 2, int(response["data"].(map[string]interface{})["consecutive_failures"].(float64)))
+
+	code, response = callWebhookApi(router, http.MethodGet, "/api/webhooks/"+webhookId+"/deliveries", "")
+	assert.Equal(t, 200, code)
+	delivery := response["data"].([]interface{})[0].(map[string]interface{})
+	deliveryId := strconv.Itoa(int(delivery["id"].(float64)))
+	code, response = callWebhookApi(router, http.MethodPost, "/api/webhooks/"+webhookId+"/deliveries/"+deliveryId+"/redeliver", "")
+	assert.Equal(t, 409, code)
+	assert.Equal(t, "CONFLICT", response["status"])
+}
@@ -0,0 +1,253 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/event"
+	"project-restful-api/model/domain"
+	"project-restful-api/webhook"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const webhookTestSecret = "whsec_0123456789abcdef"
+
+type memoryWebhookStore struct {
+	mutex      sync.Mutex
+	deliveries map[int]*domain.WebhookDelivery
+	attempts   []domain.WebhookAttempt
+	url        string
+}
+
+func newMemoryWebhookStore(url string, deliveries ...domain.WebhookDelivery) *memoryWebhookStore {
+	store := &memoryWebhookStore{deliveries: map[int]*domain.WebhookDelivery{}, url: url}
+	for i := range deliveries {
+		store.deliveries[deliveries[i].Id] = &deliveries[i]
+	}
+	return store
+}
+
+func (store *memoryWebhookStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) []domain.WebhookJob {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var jobs []domain.WebhookJob
+	now := time.Now()
+	for _, delivery := range store.deliveries {
+		if len(jobs) < limit && delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			jobs = append(jobs, domain.WebhookJob{Delivery: *delivery, Url: store.url, Secret: webhookTestSecret})
+			delivery.NextAttemptAt = now.Add(lease)
+		}
+	}
+	return jobs
+}
+
+func (store *memoryWebhookStore) RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.deliveries[delivery.Id] = &delivery
+	store.attempts = append(store.attempts, attempt)
+}
+
+func (store *memoryWebhookStore) delivery(id int) domain.WebhookDelivery {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return *store.deliveries[id]
+}
+
+func (store *memoryWebhookStore) due(id int) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.deliveries[id].NextAttemptAt = time.Now()
+}
+
+func newWebhookTestDelivery() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		Id:            7,
+		WebhookId:     1,
+		EventId:       "evt_1",
+		EventType:     domain.CategoryCreated,
+		Payload:       `{"id":"evt_1","type":"category.created","data":{"id":1,"name":"Gadget"}}`,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+func newWebhookTestConfig() webhook.Config {
+	return webhook.Config{
+		Workers:             2,
+		BatchSize:           10,
+		PollInterval:        20 * time.Millisecond,
+		Timeout:             time.Second,
+		Lease:               time.Minute,
+		MaxAttempts:         3,
+		DisableAfter:        5,
+		Backoff:             webhook.Backoff{Initial: time.Second, Max: time.Minute, Multiplier: 2},
+		AllowPrivateTargets: true,
+	}
+}
+
+func TestWebhookDispatcherDeliversSignedPayload(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		err := webhook.Verify(webhookTestSecret, request.Header.Get(webhook.HeaderTimestamp), request.Header.Get(webhook.HeaderSignature), body, time.Now())
+		assert.Nil(t, err)
+		assert.JSONEq(t, newWebhookTestDelivery().Payload, string(body))
+		received <- request
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	store := newMemoryWebhookStore(receiver.URL, newWebhookTestDelivery())
+	dispatcher := webhook.NewDispatcher(store, nil, newWebhookTestConfig())
+	assert.Equal(t, 1, dispatcher.DispatchOnce())
+
+	request := <-received
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "category.created", request.Header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "evt_1", request.Header.Get(webhook.HeaderEventId))
+	assert.Equal(t, "7", request.Header.Get(webhook.HeaderDelivery))
+
+	delivery := store.delivery(7)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, 204, delivery.LastStatusCode)
+	assert.False(t, delivery.DeliveredAt.IsZero())
+	assert.Len(t, store.attempts, 1)
+	assert.Equal(t, "", store.attempts[0].Error)
+	assert.Equal(t, 0, dispatcher.DispatchOnce())
+}
+
+func TestWebhookDispatcherRetriesWithBackoffUntilExhausted(t *testing.T) {
+	calls := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls++
+		if calls == 2 {
+			http.Redirect(writer, request, "/elsewhere", http.StatusFound)
+			return
+		}
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	store := newMemoryWebhookStore(receiver.URL, newWebhookTestDelivery())
+	dispatcher := webhook.NewDispatcher(store, nil, newWebhookTestConfig())
+
+	dispatcher.DispatchOnce()
+	delivery := store.delivery(7)
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 503, delivery.LastStatusCode)
+	assert.Equal(t, "receiver responded with 503 Service Unavailable", delivery.LastError)
+	assert.Equal(t, time.Second, delivery.NextAttemptAt.Sub(store.attempts[0].AttemptedAt))
+	assert.Equal(t, 0, dispatcher.DispatchOnce())
+
+	store.due(7)
+	dispatcher.DispatchOnce()
+	delivery = store.delivery(7)
+	assert.Equal(t, 302, delivery.LastStatusCode)
+	assert.Equal(t, 2*time.Second, delivery.NextAttemptAt.Sub(store.attempts[1].AttemptedAt))
+
+	store.due(7)
+	dispatcher.DispatchOnce()
+	delivery = store.delivery(7)
+	assert.Equal(t, domain.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, store.attempts, 3)
+}
+
+func TestWebhookDispatcherRefusesPrivateTargets(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	config := newWebhookTestConfig()
+	config.AllowPrivateTargets = false
+	store := newMemoryWebhookStore(receiver.URL, newWebhookTestDelivery())
+	dispatcher := webhook.NewDispatcher(store, nil, config)
+	assert.Equal(t, 1, dispatcher.DispatchOnce())
+
+	assert.False(t, called)
+	assert.Equal(t, domain.WebhookDeliveryPending, store.delivery(7).Status)
+	assert.Contains(t, store.attempts[0].Error, "is not a public address")
+
+	for _, address := range []string{"127.0.0.1", "::1", "10.1.2.3", "192.168.0.10", "169.254.169.254", "100.64.0.1", "0.0.0.0", "fe80::1"} {
+		assert.False(t, webhook.PublicAddress(net.ParseIP(address)), address)
+	}
+	assert.True(t, webhook.PublicAddress(net.ParseIP("93.184.216.34")))
+}
+
+func TestWebhookDispatcherIgnoresProxyEnvironment(t *testing.T) {
+	dispatcher := webhook.NewDispatcher(newMemoryWebhookStore(""), nil, newWebhookTestConfig())
+	transport, ok := dispatcher.Client.Transport.(*http.Transport)
+
+	assert.True(t, ok)
+	assert.Nil(t, transport.Proxy)
+}
+
+func TestWebhookDispatcherRunsUntilCancelled(t *testing.T) {
+	received := make(chan string, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received <- request.Header.Get(webhook.HeaderDelivery)
+	}))
+	defer receiver.Close()
+
+	delivery := newWebhookTestDelivery()
+	delivery.NextAttemptAt = time.Now().Add(time.Hour)
+	store := newMemoryWebhookStore(receiver.URL, delivery)
+	broker := event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10})
+	config := newWebhookTestConfig()
+	config.PollInterval = time.Hour
+	dispatcher := webhook.NewDispatcher(store, broker, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	store.due(7)
+	broker.Publish(domain.CategoryCreated, nil)
+	assert.Equal(t, strconv.Itoa(7), <-received)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop")
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	signature := webhook.Sign(webhookTestSecret, now, body)
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Nil(t, webhook.Verify(webhookTestSecret, "1700000000", signature, body, now.Add(time.Minute)))
+	assert.Equal(t, webhook.ErrSignatureMismatch, webhook.Verify(webhookTestSecret, "1700000000", signature, []byte(`{"id":"evt_2"}`), now))
+	assert.Equal(t, webhook.ErrSignatureMismatch, webhook.Verify("another-secret-value", "1700000000", signature, body, now))
+	assert.Equal(t, webhook.ErrTimestampExpired, webhook.Verify(webhookTestSecret, "1700000000", signature, body, now.Add(time.Hour)))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	backoff := webhook.Backoff{Initial: 10 * time.Second, Max: time.Hour, Multiplier: 3}
+
+	assert.Equal(t, 10*time.Second, backoff.Delay(1))
+	assert.Equal(t, 30*time.Second, backoff.Delay(2))
+	assert.Equal(t, 270*time.Second, backoff.Delay(4))
+	assert.Equal(t, time.Hour, backoff.Delay(10))
+}
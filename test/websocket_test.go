@@ -0,0 +1,249 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"project-restful-api/app"
+	"project-restful-api/controller"
+	"project-restful-api/event"
+	"project-restful-api/exception"
+	"project-restful-api/middleware"
+	"project-restful-api/model/domain"
+	"project-restful-api/model/web"
+	"project-restful-api/repository"
+	"project-restful-api/tracing"
+	"project-restful-api/websocket"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+type publishingCategoryService struct {
+	*memoryCategoryService
+	events event.Publisher
+}
+
+func (service publishingCategoryService) Create(ctx context.Context, request web.CategoryCreateRequest) web.CategoryResponse {
+	category := service.memoryCategoryService.Create(ctx, request)
+	service.events.Publish(domain.CategoryCreated, category)
+	return category
+}
+
+func setupWebSocketServer(broker *event.Broker) *httptest.Server {
+	categoryService := publishingCategoryService{
+		memoryCategoryService: &memoryCategoryService{categories: []web.CategoryResponse{{Id: 1, Name: "Gadget"}}},
+		events:                broker,
+	}
+	webSocketController := controller.NewWebSocketController(categoryService, broker, app.NewWebSocketConfig(app.NewCorsConfig()))
+	authGuard := middleware.NewAuthGuard(app.NewAuthGuardConfig(), nil)
+	authMiddleware := middleware.NewAuthMiddleware(app.NewApiKeys(), repository.NewSessionRepository(), app.NewTwoFactorPolicy(), authGuard, middleware.NewHmacVerifier(app.NewHmacConfig()), app.NewTrustedProxies())
+
+	router := httprouter.New()
+	router.GET("/api/ws", authMiddleware.Authenticate(webSocketController.Connect))
+	router.PanicHandler = exception.ErrorHandler
+	return httptest.NewServer(app.NewHandler(router, app.NewTrustedProxies(), tracing.Default(), app.NewServerConfig(), app.NewCorsConfig(), app.NewCompressionConfig(), app.NewApiVersions()))
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server) (*websocket.Conn, *http.Response) {
+	header := http.Header{}
+	header.Add("X-API-Key", "RAHASIA")
+	header.Add("Accept-Encoding", "gzip")
+	conn, response, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/ws", header, websocket.Config{})
+	assert.Nil(t, err)
+	return conn, response
+}
+
+func sendWebSocket(conn *websocket.Conn, message string) {
+	conn.Send(websocket.TextMessage, []byte(message))
+}
+
+func readWebSocket(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	_, payload, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	message := map[string]interface{}{}
+	json.Unmarshal(payload, &message)
+	return message
+}
+
+func TestWebSocketRequiresAuthentication(t *testing.T) {
+	server := setupWebSocketServer(event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10}))
+	defer server.Close()
+
+	_, response, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/ws", nil, websocket.Config{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 401, response.StatusCode)
+}
+
+func TestWebSocketBrowserHandshake(t *testing.T) {
+	server := setupWebSocketServer(event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/ws"
+
+	header := http.Header{}
+	header.Add("Origin", "http://localhost:5173")
+	header.Add("Sec-WebSocket-Protocol", app.WebSocketSubprotocol+", "+middleware.ApiKeySubprotocolPrefix+"RAHASIA")
+	conn, response, err := websocket.Dial(url, header, websocket.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, app.WebSocketSubprotocol, response.Header.Get("Sec-WebSocket-Protocol"))
+	sendWebSocket(conn, `{"type": "subscribe", "id": "1"}`)
+	assert.Equal(t, "ack", readWebSocket(t, conn)["type"])
+	conn.Close(websocket.CloseNormal, "")
+
+	header.Set("Sec-WebSocket-Protocol", middleware.ApiKeySubprotocolPrefix+"SALAH")
+	_, response, err = websocket.Dial(url, header, websocket.Config{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 401, response.StatusCode)
+
+	header.Set("Origin", "https://evil.example")
+	header.Set("Sec-WebSocket-Protocol", app.WebSocketSubprotocol+", "+middleware.ApiKeySubprotocolPrefix+"RAHASIA")
+	_, response, err = websocket.Dial(url, header, websocket.Config{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 403, response.StatusCode)
+}
+
+func TestWebSocketRejectsPlainRequest(t *testing.T) {
+	server := setupWebSocketServer(event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/api/ws", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	response, err := http.DefaultClient.Do(request)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, 426, response.StatusCode)
+	assert.JSONEq(t, `{"code":426,"status":"UPGRADE REQUIRED","data":"request must upgrade the connection to websocket"}`, string(body))
+}
+
+func TestWebSocketSubscribeAndMutate(t *testing.T) {
+	broker := event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10})
+	server := setupWebSocketServer(broker)
+	defer server.Close()
+
+	writer, response := dialWebSocket(t, server)
+	defer writer.Close(websocket.CloseNormal, "")
+	assert.NotEmpty(t, response.Header.Get("X-Request-Id"))
+	assert.Empty(t, response.Header.Get("Content-Encoding"))
+
+	watcher, _ := dialWebSocket(t, server)
+	defer watcher.Close(websocket.CloseNormal, "")
+	sendWebSocket(watcher, `{"type": "subscribe", "id": "s1", "categories": [2]}`)
+	assert.Equal(t, map[string]interface{}{"type": "ack", "id": "s1", "data": map[string]interface{}{"all": false, "categories": []interface{}{2.0}}}, readWebSocket(t, watcher))
+
+	sendWebSocket(writer, `{"type": "create", "id": "c1", "data": {"name": "Food"}}`)
+	assert.Equal(t, map[string]interface{}{"type": "ack", "id": "c1", "data": map[string]interface{}{"id": 2.0, "name": "Food"}}, readWebSocket(t, writer))
+
+	broker.Publish(domain.CategoryUpdated, web.CategoryResponse{Id: 1, Name: "Book"})
+	broker.Publish(domain.CategoryDeleted, web.CategoryResponse{Id: 2, Name: "Food"})
+
+	message := readWebSocket(t, watcher)
+	assert.Equal(t, "category.created", message["event"])
+	assert.Equal(t, map[string]interface{}{"id": 2.0, "name": "Food"}, message["data"])
+	message = readWebSocket(t, watcher)
+	assert.Equal(t, "category.deleted", message["event"])
+	assert.Equal(t, 3.0, message["eventId"])
+}
+
+func TestWebSocketReportsErrors(t *testing.T) {
+	server := setupWebSocketServer(event.NewBroker(event.BrokerConfig{SubscriberBuffer: 10}))
+	defer server.Close()
+
+	conn, _ := dialWebSocket(t, server)
+	defer conn.Close(websocket.CloseNormal, "")
+
+	sendWebSocket(conn, `{"type": "rename", "id": "r1"}`)
+	assert.Equal(t, map[string]interface{}{"type": "error", "id": "r1", "data": map[string]interface{}{
+		"code": 400.0, "status": "BAD REQUEST", "data": "message parameter type must be one of subscribe, unsubscribe, create, update, delete",
+	}}, readWebSocket(t, conn))
+
+	sendWebSocket(conn, `{"type": "update", "id": "u1", "data": {"id": 9, "name": "Book"}}`)
+	message := readWebSocket(t, conn)
+	assert.Equal(t, 404.0, message["data"].(map[string]interface{})["code"])
+
+	sendWebSocket(conn, `not json`)
+	message = readWebSocket(t, conn)
+	assert.Equal(t, "error", message["type"])
+	assert.Contains(t, message["data"].(map[string]interface{})["data"], "message parameter message is not valid")
+}
+
+type pipeResponseWriter struct {
+	header http.Header
+	conn   net.Conn
+}
+
+func (writer *pipeResponseWriter) Header() http.Header {
+	return writer.header
+}
+
+func (writer *pipeResponseWriter) Write(bytes []byte) (int, error) {
+	return len(bytes), nil
+}
+
+func (writer *pipeResponseWriter) WriteHeader(status int) {}
+
+func (writer *pipeResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return writer.conn, bufio.NewReadWriter(bufio.NewReader(writer.conn), bufio.NewWriter(writer.conn)), nil
+}
+
+func upgradePipe(t *testing.T, config websocket.Config) (*websocket.Conn, *bufio.Reader) {
+	serverEnd, clientEnd := net.Pipe()
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/ws", nil)
+	request.Header.Add("Connection", "keep-alive, Upgrade")
+	request.Header.Add("Upgrade", "websocket")
+	request.Header.Add("Sec-WebSocket-Version", "13")
+	request.Header.Add("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+	upgraded := make(chan *websocket.Conn)
+	go func() {
+		conn, err := websocket.Upgrade(&pipeResponseWriter{header: http.Header{}, conn: serverEnd}, request, config)
+		assert.Nil(t, err)
+		upgraded <- conn
+	}()
+
+	reader := bufio.NewReader(clientEnd)
+	response, err := http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 101, response.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", response.Header.Get("Sec-WebSocket-Accept"))
+	return <-upgraded, reader
+}
+
+func TestWebSocketClosesWhenPongsStop(t *testing.T) {
+	conn, reader := upgradePipe(t, websocket.Config{PingInterval: 20 * time.Millisecond, PongTimeout: 100 * time.Millisecond})
+
+	received := make(chan []byte)
+	go func() {
+		bytes, _ := io.ReadAll(reader)
+		received <- bytes
+	}()
+
+	_, _, err := conn.ReadMessage()
+	assert.NotNil(t, err)
+	assert.Contains(t, string(<-received), "\x89\x00")
+}
+
+func TestWebSocketDropsSlowClient(t *testing.T) {
+	conn, _ := upgradePipe(t, websocket.Config{SendQueueSize: 1, WriteTimeout: 50 * time.Millisecond})
+
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = conn.Send(websocket.TextMessage, []byte("event"))
+	}
+	assert.Equal(t, websocket.ErrSendQueueFull, err)
+
+	select {
+	case <-conn.Done():
+	case <-time.After(time.Second):
+		t.Fatal("connection was not closed")
+	}
+	assert.Equal(t, websocket.ErrClosed, conn.Send(websocket.TextMessage, []byte("event")))
+}
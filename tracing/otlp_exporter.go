@@ -0,0 +1,143 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OtlpHttpExporter struct {
+	Endpoint    string
+	ServiceName string
+	Headers     map[string]string
+	Client      *http.Client
+}
+
+func NewOtlpHttpExporter(endpoint string, serviceName string) *OtlpHttpExporter {
+	return &OtlpHttpExporter{
+		Endpoint:    strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		ServiceName: serviceName,
+		Headers:     map[string]string{},
+		Client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type otlpKeyValue struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string         `json:"traceId"`
+	SpanId            string         `json:"spanId"`
+	ParentSpanId      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+func (exporter *OtlpHttpExporter) Export(ctx context.Context, spans []*Span) error {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, toOtlpSpan(span))
+	}
+
+	payload := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpKeyValue{stringAttribute("service.name", exporter.ServiceName)},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "project-restful-api/tracing"},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, exporter.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range exporter.Headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := exporter.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("otlp collector responded with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (exporter *OtlpHttpExporter) Shutdown(ctx context.Context) error {
+	exporter.Client.CloseIdleConnections()
+	return nil
+}
+
+func toOtlpSpan(span *Span) otlpSpan {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	keys := make([]string, 0, len(span.Attributes))
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		attributes = append(attributes, stringAttribute(key, span.Attributes[key]))
+	}
+
+	result := otlpSpan{
+		TraceId:           span.Context.TraceId.String(),
+		SpanId:            span.Context.SpanId.String(),
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		Attributes:        attributes,
+		Status:            otlpStatus{Code: 1},
+	}
+	if span.ParentSpanId.IsValid() {
+		result.ParentSpanId = span.ParentSpanId.String()
+	}
+	if span.Error != "" {
+		result.Status = otlpStatus{Code: 2, Message: span.Error}
+	}
+	return result
+}
+
+func stringAttribute(key string, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: map[string]string{"stringValue": value}}
+}
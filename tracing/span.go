@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	SpanKindInternal = 1
+	SpanKindServer   = 2
+	SpanKindClient   = 3
+)
+
+type Span struct {
+	mutex        sync.Mutex
+	tracer       *Tracer
+	ended        bool
+	Name         string
+	Kind         int
+	Context      SpanContext
+	ParentSpanId SpanId
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]string
+	Error        string
+}
+
+func (span *Span) SetName(name string) {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.Name = name
+}
+
+func (span *Span) SetAttribute(key string, value interface{}) {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.Attributes[key] = fmt.Sprint(value)
+}
+
+func (span *Span) SetError(err interface{}) {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.Error = fmt.Sprint(err)
+}
+
+// End finishes the span. When deferred it also records a panic passing
+// through as the span error before re-panicking, the same way
+// helper.CommitOrRollback does for transactions.
+func (span *Span) End() {
+	err := recover()
+	if err != nil {
+		span.SetError(err)
+	}
+	span.finish()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (span *Span) finish() {
+	span.mutex.Lock()
+	if span.ended {
+		span.mutex.Unlock()
+		return
+	}
+	span.ended = true
+	span.EndTime = time.Now()
+	span.mutex.Unlock()
+
+	if span.Context.Sampled && span.tracer != nil {
+		span.tracer.enqueue(span)
+	}
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+type TraceId [16]byte
+
+type SpanId [8]byte
+
+func (id TraceId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id TraceId) IsValid() bool {
+	return id != TraceId{}
+}
+
+func (id SpanId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanId) IsValid() bool {
+	return id != SpanId{}
+}
+
+type SpanContext struct {
+	TraceId TraceId
+	SpanId  SpanId
+	Sampled bool
+}
+
+func (spanContext SpanContext) IsValid() bool {
+	return spanContext.TraceId.IsValid() && spanContext.SpanId.IsValid()
+}
+
+func (spanContext SpanContext) Traceparent() string {
+	flags := "00"
+	if spanContext.Sampled {
+		flags = "01"
+	}
+	return "00-" + spanContext.TraceId.String() + "-" + spanContext.SpanId.String() + "-" + flags
+}
+
+func ParseTraceparent(header string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+
+	spanContext := SpanContext{}
+	if !decodeHex(parts[1], spanContext.TraceId[:]) || !decodeHex(parts[2], spanContext.SpanId[:]) {
+		return SpanContext{}, false
+	}
+
+	flags := make([]byte, 1)
+	if !decodeHex(parts[3], flags) {
+		return SpanContext{}, false
+	}
+	spanContext.Sampled = flags[0]&0x01 == 0x01
+
+	if !spanContext.IsValid() {
+		return SpanContext{}, false
+	}
+	return spanContext, true
+}
+
+func decodeHex(value string, target []byte) bool {
+	if len(value) != hex.EncodedLen(len(target)) || strings.ToLower(value) != value {
+		return false
+	}
+	_, err := hex.Decode(target, []byte(value))
+	return err == nil
+}
+
+func newTraceId() TraceId {
+	var id TraceId
+	rand.Read(id[:])
+	return id
+}
+
+func newSpanId() SpanId {
+	var id SpanId
+	rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,191 @@
+package tracing
+
+import (
+	"context"
+	"project-restful-api/helper"
+	"sync"
+	"time"
+)
+
+type Exporter interface {
+	Export(ctx context.Context, spans []*Span) error
+	Shutdown(ctx context.Context) error
+}
+
+type spanContextKey struct{}
+
+type tracerContextKey struct{}
+
+type Tracer struct {
+	Exporter      Exporter
+	BatchSize     int
+	FlushInterval time.Duration
+	queue         chan *Span
+	flush         chan chan struct{}
+	stopOnce      sync.Once
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+var defaultTracer = NewTracer(nil)
+
+func NewTracer(exporter Exporter) *Tracer {
+	tracer := &Tracer{
+		Exporter:      exporter,
+		BatchSize:     100,
+		FlushInterval: 2 * time.Second,
+		queue:         make(chan *Span, 2048),
+		flush:         make(chan chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if exporter != nil {
+		go tracer.run()
+	}
+	return tracer
+}
+
+func SetDefault(tracer *Tracer) {
+	defaultTracer = tracer
+}
+
+func Default() *Tracer {
+	return defaultTracer
+}
+
+// FromContext returns the tracer that started the span in ctx, so child
+// spans follow the tracer injected into the handler instead of the default.
+func FromContext(ctx context.Context) *Tracer {
+	if tracer, ok := ctx.Value(tracerContextKey{}).(*Tracer); ok {
+		return tracer
+	}
+	return defaultTracer
+}
+
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	return FromContext(ctx).Start(ctx, name, SpanKindInternal)
+}
+
+func (tracer *Tracer) Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{
+		tracer:     tracer,
+		Name:       name,
+		Kind:       kind,
+		StartTime:  time.Now(),
+		Attributes: map[string]string{},
+	}
+	if parent.IsValid() {
+		span.Context = SpanContext{TraceId: parent.TraceId, SpanId: newSpanId(), Sampled: parent.Sampled}
+		span.ParentSpanId = parent.SpanId
+	} else {
+		span.Context = SpanContext{TraceId: newTraceId(), SpanId: newSpanId(), Sampled: true}
+	}
+	if FromContext(ctx) != tracer {
+		ctx = context.WithValue(ctx, tracerContextKey{}, tracer)
+	}
+	return ContextWithSpanContext(ctx, span.Context), span
+}
+
+func ContextWithSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext)
+}
+
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	spanContext, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return spanContext
+}
+
+func (tracer *Tracer) enqueue(span *Span) {
+	if tracer.Exporter == nil {
+		return
+	}
+	select {
+	case tracer.queue <- span:
+	default:
+	}
+}
+
+func (tracer *Tracer) run() {
+	ticker := time.NewTicker(tracer.FlushInterval)
+	defer ticker.Stop()
+	defer close(tracer.done)
+
+	var batch []*Span
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := tracer.Exporter.Export(ctx, batch); err != nil {
+			helper.LogError("trace export failed", err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case span := <-tracer.queue:
+			batch = append(batch, span)
+			if len(batch) >= tracer.BatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case flushed := <-tracer.flush:
+			tracer.drain(&batch)
+			export()
+			close(flushed)
+		case <-tracer.stop:
+			tracer.drain(&batch)
+			export()
+			return
+		}
+	}
+}
+
+func (tracer *Tracer) drain(batch *[]*Span) {
+	for {
+		select {
+		case span := <-tracer.queue:
+			*batch = append(*batch, span)
+		default:
+			return
+		}
+	}
+}
+
+func (tracer *Tracer) ForceFlush(ctx context.Context) {
+	if tracer.Exporter == nil {
+		return
+	}
+	flushed := make(chan struct{})
+	select {
+	case tracer.flush <- flushed:
+	case <-tracer.done:
+		return
+	case <-ctx.Done():
+		return
+	}
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+	}
+}
+
+func (tracer *Tracer) Shutdown(ctx context.Context) error {
+	if tracer.Exporter == nil {
+		return nil
+	}
+	tracer.stopOnce.Do(func() {
+		close(tracer.stop)
+	})
+	select {
+	case <-tracer.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return tracer.Exporter.Shutdown(ctx)
+}
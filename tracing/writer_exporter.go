@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type WriterExporter struct {
+	mutex  sync.Mutex
+	Writer io.Writer
+	closer io.Closer
+}
+
+func NewStdoutExporter() *WriterExporter {
+	return &WriterExporter{Writer: os.Stdout}
+}
+
+func NewFileExporter(path string) (*WriterExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &WriterExporter{Writer: file, closer: file}, nil
+}
+
+func (exporter *WriterExporter) Export(ctx context.Context, spans []*Span) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	encoder := json.NewEncoder(exporter.Writer)
+	for _, span := range spans {
+		span.mutex.Lock()
+		entry := map[string]interface{}{
+			"trace_id":    span.Context.TraceId.String(),
+			"span_id":     span.Context.SpanId.String(),
+			"name":        span.Name,
+			"kind":        span.Kind,
+			"start_time":  span.StartTime.UTC().Format(time.RFC3339Nano),
+			"duration_ms": float64(span.EndTime.Sub(span.StartTime).Microseconds()) / 1000,
+			"attributes":  span.Attributes,
+		}
+		if span.ParentSpanId.IsValid() {
+			entry["parent_span_id"] = span.ParentSpanId.String()
+		}
+		if span.Error != "" {
+			entry["error"] = span.Error
+		}
+		span.mutex.Unlock()
+
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (exporter *WriterExporter) Shutdown(ctx context.Context) error {
+	if exporter.closer != nil {
+		return exporter.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"math"
+	"time"
+)
+
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+func (backoff Backoff) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := float64(backoff.Initial) * math.Pow(backoff.Multiplier, float64(attempt-1))
+	if backoff.Max > 0 && delay > float64(backoff.Max) {
+		return backoff.Max
+	}
+	return time.Duration(delay)
+}
+
+type Config struct {
+	Workers      int
+	BatchSize    int
+	PollInterval time.Duration
+	Timeout      time.Duration
+	Lease        time.Duration
+	MaxAttempts  int
+	DisableAfter int
+	Backoff      Backoff
+	// AllowPrivateTargets lets deliveries reach loopback, link-local and
+	// private addresses. Only tests against a local receiver should set it.
+	AllowPrivateTargets bool
+}
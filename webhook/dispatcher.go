@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"project-restful-api/event"
+	"project-restful-api/helper"
+	"project-restful-api/metrics"
+	"project-restful-api/model/domain"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	userAgent        = "Todolist-Webhook/1.0"
+	maxResponseBytes = 64 << 10
+)
+
+type Store interface {
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) []domain.WebhookJob
+	RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt)
+}
+
+type Dispatcher struct {
+	Store  Store
+	Broker *event.Broker
+	Client *http.Client
+	Config Config
+}
+
+func NewDispatcher(store Store, broker *event.Broker, config Config) *Dispatcher {
+	return &Dispatcher{
+		Store:  store,
+		Broker: broker,
+		Client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: newTransport(config),
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Config: config,
+	}
+}
+
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func newTransport(config Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would dial the target on our behalf and bypass the address
+	// check below, so deliveries always connect directly.
+	transport.Proxy = nil
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !config.AllowPrivateTargets {
+		dialer.Control = func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !PublicAddress(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		}
+	}
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// PublicAddress reports whether ip may receive webhook deliveries. The check
+// runs in the dialer on the resolved address, so DNS names pointing at
+// internal hosts are refused as well.
+func PublicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+func (dispatcher *Dispatcher) Run(ctx context.Context) {
+	var wakeups <-chan event.Event
+	if dispatcher.Broker != nil {
+		subscription, _ := dispatcher.Broker.Subscribe(nil, 0)
+		defer subscription.Close()
+		wakeups = subscription.Events
+	}
+	ticker := time.NewTicker(dispatcher.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			claimed := dispatcher.DispatchOnce()
+			if claimed == 0 || claimed < dispatcher.Config.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-wakeups:
+			if !ok {
+				wakeups = nil
+			}
+		}
+	}
+}
+
+func (dispatcher *Dispatcher) DispatchOnce() int {
+	ctx := context.Background()
+	jobs := dispatcher.claim(ctx)
+
+	workers := dispatcher.Config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	semaphore := make(chan struct{}, workers)
+	var group sync.WaitGroup
+	for _, job := range jobs {
+		group.Add(1)
+		semaphore <- struct{}{}
+		go func(job domain.WebhookJob) {
+			defer group.Done()
+			defer func() { <-semaphore }()
+			dispatcher.process(ctx, job)
+		}(job)
+	}
+	group.Wait()
+	return len(jobs)
+}
+
+func (dispatcher *Dispatcher) claim(ctx context.Context) (jobs []domain.WebhookJob) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			helper.LogError("webhook deliveries could not be claimed", fmt.Errorf("%v", recovered))
+			jobs = nil
+		}
+	}()
+	return dispatcher.Store.ClaimDeliveries(ctx, dispatcher.Config.BatchSize, dispatcher.Config.Lease)
+}
+
+func (dispatcher *Dispatcher) process(ctx context.Context, job domain.WebhookJob) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			helper.LogError("webhook attempt could not be recorded for delivery "+strconv.Itoa(job.Delivery.Id), fmt.Errorf("%v", recovered))
+		}
+	}()
+
+	attempt := dispatcher.send(ctx, job)
+	delivery := job.Delivery
+	delivery.Attempts++
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = domain.WebhookDeliveryDelivered
+		delivery.DeliveredAt = attempt.AttemptedAt
+	case delivery.Attempts >= dispatcher.Config.MaxAttempts:
+		delivery.Status = domain.WebhookDeliveryFailed
+	default:
+		delivery.NextAttemptAt = attempt.AttemptedAt.Add(dispatcher.Config.Backoff.Delay(delivery.Attempts))
+	}
+	dispatcher.Store.RecordAttempt(ctx, delivery, attempt)
+
+	switch delivery.Status {
+	case domain.WebhookDeliveryDelivered, domain.WebhookDeliveryFailed:
+		metrics.WebhookAttemptsTotal.Inc(delivery.Status)
+	default:
+		metrics.WebhookAttemptsTotal.Inc("retry")
+	}
+}
+
+func (dispatcher *Dispatcher) send(ctx context.Context, job domain.WebhookJob) domain.WebhookAttempt {
+	attempt := domain.WebhookAttempt{DeliveryId: job.Delivery.Id, AttemptedAt: time.Now()}
+	body := []byte(job.Delivery.Payload)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, job.Url, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(HeaderEvent, job.Delivery.EventType)
+	request.Header.Set(HeaderEventId, job.Delivery.EventId)
+	request.Header.Set(HeaderDelivery, strconv.Itoa(job.Delivery.Id))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(attempt.AttemptedAt.Unix(), 10))
+	request.Header.Set(HeaderSignature, Sign(job.Secret, attempt.AttemptedAt, body))
+
+	response, err := dispatcher.Client.Do(request)
+	attempt.Duration = time.Since(attempt.AttemptedAt)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBytes))
+	response.Body.Close()
+
+	attempt.StatusCode = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		attempt.Error = "receiver responded with " + response.Status
+	}
+	return attempt
+}
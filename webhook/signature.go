@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"errors"
+	"project-restful-api/helper"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderEventId    = "X-Webhook-Id"
+	HeaderDelivery   = "X-Webhook-Delivery"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+	signaturePrefix  = "sha256="
+	defaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrSignatureMismatch = errors.New("webhook signature does not match")
+	ErrTimestampExpired  = errors.New("webhook timestamp is outside the tolerance window")
+)
+
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	message := strconv.FormatInt(timestamp.Unix(), 10) + "." + string(body)
+	return signaturePrefix + helper.HmacSHA256Hex(secret, []byte(message))
+}
+
+func Verify(secret string, timestampHeader string, signatureHeader string, body []byte, now time.Time) error {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrTimestampExpired
+	}
+	timestamp := time.Unix(unix, 0)
+	if now.Sub(timestamp) > defaultTolerance || timestamp.Sub(now) > defaultTolerance {
+		return ErrTimestampExpired
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signatureHeader)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package websocket
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const defaultSendQueueSize = 16
+
+var (
+	ErrClosed        = errors.New("websocket: connection closed")
+	ErrSendQueueFull = errors.New("websocket: send queue full")
+)
+
+type Config struct {
+	MaxMessageSize int64
+	SendQueueSize  int
+	PingInterval   time.Duration
+	PongTimeout    time.Duration
+	WriteTimeout   time.Duration
+	Subprotocols   []string
+	CheckOrigin    func(origin string) bool
+}
+
+type outgoing struct {
+	opcode  byte
+	payload []byte
+}
+
+type Conn struct {
+	Config    Config
+	netConn   net.Conn
+	reader    *bufio.Reader
+	client    bool
+	send      chan outgoing
+	done      chan struct{}
+	writeLock sync.Mutex
+	closeOnce sync.Once
+}
+
+func newConn(netConn net.Conn, reader *bufio.Reader, client bool, config Config) *Conn {
+	if config.SendQueueSize <= 0 {
+		config.SendQueueSize = defaultSendQueueSize
+	}
+	conn := &Conn{
+		Config:  config,
+		netConn: netConn,
+		reader:  reader,
+		client:  client,
+		send:    make(chan outgoing, config.SendQueueSize),
+		done:    make(chan struct{}),
+	}
+	go conn.writeLoop()
+	return conn
+}
+
+func (conn *Conn) ReadMessage() (byte, []byte, error) {
+	var opcode byte
+	var message []byte
+	for {
+		if conn.Config.PongTimeout > 0 {
+			conn.netConn.SetReadDeadline(time.Now().Add(conn.Config.PongTimeout))
+		}
+		frame, err := readFrame(conn.reader, !conn.client, conn.Config.MaxMessageSize)
+		if err != nil {
+			return 0, nil, conn.fail(err)
+		}
+
+		switch frame.opcode {
+		case PingMessage:
+			if err := conn.write(PongMessage, frame.payload); err != nil {
+				return 0, nil, conn.fail(err)
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			closeError := parseClosePayload(frame.payload)
+			conn.Close(closeError.Code, "")
+			return 0, nil, closeError
+		case ContinuationMessage:
+			if opcode == 0 {
+				return 0, nil, conn.fail(&CloseError{Code: CloseProtocolError, Reason: "unexpected continuation frame"})
+			}
+		default:
+			if opcode != 0 {
+				return 0, nil, conn.fail(&CloseError{Code: CloseProtocolError, Reason: "expected continuation frame"})
+			}
+			opcode = frame.opcode
+		}
+
+		if conn.Config.MaxMessageSize > 0 && int64(len(message)+len(frame.payload)) > conn.Config.MaxMessageSize {
+			return 0, nil, conn.fail(&CloseError{Code: CloseMessageTooBig, Reason: "message too big"})
+		}
+		message = append(message, frame.payload...)
+		if !frame.fin {
+			continue
+		}
+		if opcode == TextMessage && !utf8.Valid(message) {
+			return 0, nil, conn.fail(&CloseError{Code: CloseInvalidPayload, Reason: "text message is not valid UTF-8"})
+		}
+		return opcode, message, nil
+	}
+}
+
+func (conn *Conn) Send(opcode byte, payload []byte) error {
+	select {
+	case <-conn.done:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case conn.send <- outgoing{opcode: opcode, payload: payload}:
+		return nil
+	default:
+		conn.Close(CloseTryAgainLater, "client is not keeping up")
+		return ErrSendQueueFull
+	}
+}
+
+func (conn *Conn) Close(code int, reason string) error {
+	err := ErrClosed
+	conn.closeOnce.Do(func() {
+		close(conn.done)
+		err = conn.write(CloseMessage, closePayload(code, reason))
+		conn.netConn.Close()
+	})
+	return err
+}
+
+func (conn *Conn) Done() <-chan struct{} {
+	return conn.done
+}
+
+func (conn *Conn) RemoteAddr() net.Addr {
+	return conn.netConn.RemoteAddr()
+}
+
+func (conn *Conn) fail(err error) error {
+	var closeError *CloseError
+	if errors.As(err, &closeError) {
+		conn.Close(closeError.Code, closeError.Reason)
+		return err
+	}
+	conn.abort()
+	return err
+}
+
+func (conn *Conn) abort() {
+	conn.closeOnce.Do(func() {
+		close(conn.done)
+		conn.netConn.Close()
+	})
+}
+
+func (conn *Conn) write(opcode byte, payload []byte) error {
+	conn.writeLock.Lock()
+	defer conn.writeLock.Unlock()
+
+	if conn.Config.WriteTimeout > 0 {
+		conn.netConn.SetWriteDeadline(time.Now().Add(conn.Config.WriteTimeout))
+	}
+	_, err := conn.netConn.Write(appendFrame(nil, opcode, payload, conn.client))
+	return err
+}
+
+func (conn *Conn) writeLoop() {
+	var ping <-chan time.Time
+	if conn.Config.PingInterval > 0 {
+		ticker := time.NewTicker(conn.Config.PingInterval)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+
+	for {
+		var err error
+		select {
+		case <-conn.done:
+			return
+		case message := <-conn.send:
+			err = conn.write(message.opcode, message.payload)
+		case <-ping:
+			err = conn.write(PingMessage, nil)
+		}
+		if err != nil {
+			conn.abort()
+			return
+		}
+	}
+}
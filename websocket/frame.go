@@ -0,0 +1,175 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"strconv"
+)
+
+const (
+	ContinuationMessage = 0x0
+	TextMessage         = 0x1
+	BinaryMessage       = 0x2
+	CloseMessage        = 0x8
+	PingMessage         = 0x9
+	PongMessage         = 0xA
+)
+
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseAbnormal        = 1006
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+	CloseTryAgainLater   = 1013
+)
+
+const maxControlPayload = 125
+
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (err *CloseError) Error() string {
+	return "websocket: close " + strconv.Itoa(err.Code) + " " + err.Reason
+}
+
+type frame struct {
+	fin     bool
+	opcode  byte
+	payload []byte
+}
+
+func isControl(opcode byte) bool {
+	return opcode&0x8 != 0
+}
+
+func readFrame(reader *bufio.Reader, masked bool, maxSize int64) (frame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return frame{}, err
+	}
+
+	result := frame{fin: header[0]&0x80 != 0, opcode: header[0] & 0x0f}
+	if header[0]&0x70 != 0 {
+		return frame{}, &CloseError{Code: CloseProtocolError, Reason: "reserved bits must be zero"}
+	}
+	switch result.opcode {
+	case ContinuationMessage, TextMessage, BinaryMessage, CloseMessage, PingMessage, PongMessage:
+	default:
+		return frame{}, &CloseError{Code: CloseProtocolError, Reason: "unknown opcode " + strconv.Itoa(int(result.opcode))}
+	}
+	if (header[1]&0x80 != 0) != masked {
+		if masked {
+			return frame{}, &CloseError{Code: CloseProtocolError, Reason: "client frames must be masked"}
+		}
+		return frame{}, &CloseError{Code: CloseProtocolError, Reason: "server frames must not be masked"}
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(reader, extended[:]); err != nil {
+			return frame{}, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(reader, extended[:]); err != nil {
+			return frame{}, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+		if length>>63 != 0 {
+			return frame{}, &CloseError{Code: CloseProtocolError, Reason: "payload length overflows"}
+		}
+	}
+
+	if isControl(result.opcode) && (!result.fin || length > maxControlPayload) {
+		return frame{}, &CloseError{Code: CloseProtocolError, Reason: "control frames must be final and at most 125 bytes"}
+	}
+	if maxSize > 0 && length > uint64(maxSize) {
+		return frame{}, &CloseError{Code: CloseMessageTooBig, Reason: "message exceeds " + strconv.FormatInt(maxSize, 10) + " bytes"}
+	}
+
+	var key [4]byte
+	if masked {
+		if _, err := io.ReadFull(reader, key[:]); err != nil {
+			return frame{}, err
+		}
+	}
+	result.payload = make([]byte, length)
+	if _, err := io.ReadFull(reader, result.payload); err != nil {
+		return frame{}, err
+	}
+	if masked {
+		maskBytes(key, result.payload)
+	}
+	return result, nil
+}
+
+func appendFrame(buffer []byte, opcode byte, payload []byte, masked bool) []byte {
+	buffer = append(buffer, 0x80|opcode)
+
+	var maskBit byte
+	if masked {
+		maskBit = 0x80
+	}
+	length := len(payload)
+	switch {
+	case length <= 125:
+		buffer = append(buffer, maskBit|byte(length))
+	case length <= 0xffff:
+		buffer = append(buffer, maskBit|126)
+		buffer = binary.BigEndian.AppendUint16(buffer, uint16(length))
+	default:
+		buffer = append(buffer, maskBit|127)
+		buffer = binary.BigEndian.AppendUint64(buffer, uint64(length))
+	}
+
+	if !masked {
+		return append(buffer, payload...)
+	}
+	var key [4]byte
+	rand.Read(key[:])
+	buffer = append(buffer, key[:]...)
+	start := len(buffer)
+	buffer = append(buffer, payload...)
+	maskBytes(key, buffer[start:])
+	return buffer
+}
+
+func maskBytes(key [4]byte, payload []byte) {
+	for i := range payload {
+		payload[i] ^= key[i%4]
+	}
+}
+
+func closePayload(code int, reason string) []byte {
+	if code == CloseNoStatus {
+		return nil
+	}
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	return append(payload, reason...)
+}
+
+func parseClosePayload(payload []byte) *CloseError {
+	if len(payload) == 0 {
+		return &CloseError{Code: CloseNoStatus}
+	}
+	if len(payload) == 1 {
+		return &CloseError{Code: CloseProtocolError, Reason: "close payload must include a status code"}
+	}
+	return &CloseError{Code: int(binary.BigEndian.Uint16(payload)), Reason: string(payload[2:])}
+}
@@ -0,0 +1,207 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const acceptGuid = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var skippedUpgradeHeaders = map[string]bool{
+	"Content-Type":     true,
+	"Content-Length":   true,
+	"Content-Encoding": true,
+}
+
+type HandshakeError struct {
+	Status  int
+	Message string
+}
+
+func (err HandshakeError) Error() string {
+	return "websocket: " + err.Message
+}
+
+func Upgrade(writer http.ResponseWriter, request *http.Request, config Config) (*Conn, error) {
+	if request.Method != http.MethodGet {
+		return nil, HandshakeError{Status: http.StatusMethodNotAllowed, Message: "handshake must use GET"}
+	}
+	if !headerContainsToken(request.Header, "Connection", "upgrade") || !headerContainsToken(request.Header, "Upgrade", "websocket") {
+		return nil, HandshakeError{Status: http.StatusUpgradeRequired, Message: "request must upgrade the connection to websocket"}
+	}
+	if request.Header.Get("Sec-WebSocket-Version") != "13" {
+		writer.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, HandshakeError{Status: http.StatusUpgradeRequired, Message: "only websocket version 13 is supported"}
+	}
+	key := request.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, HandshakeError{Status: http.StatusBadRequest, Message: "Sec-WebSocket-Key must be a base64 encoded 16 byte value"}
+	}
+	if origin := request.Header.Get("Origin"); origin != "" && !sameOrigin(origin, request.Host) && (config.CheckOrigin == nil || !config.CheckOrigin(origin)) {
+		return nil, HandshakeError{Status: http.StatusForbidden, Message: "origin " + origin + " is not allowed"}
+	}
+	subprotocol := selectSubprotocol(Subprotocols(request), config.Subprotocols)
+	if len(Subprotocols(request)) > 0 && subprotocol == "" {
+		return nil, HandshakeError{Status: http.StatusBadRequest, Message: "none of the requested subprotocols is supported"}
+	}
+
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		return nil, HandshakeError{Status: http.StatusInternalServerError, Message: "response writer does not support hijacking"}
+	}
+	netConn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	netConn.SetDeadline(time.Time{})
+
+	var response strings.Builder
+	response.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	response.WriteString("Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n")
+	if subprotocol != "" {
+		response.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+	for name, values := range writer.Header() {
+		if skippedUpgradeHeaders[name] {
+			continue
+		}
+		for _, value := range values {
+			response.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+	response.WriteString("\r\n")
+
+	if config.WriteTimeout > 0 {
+		netConn.SetWriteDeadline(time.Now().Add(config.WriteTimeout))
+	}
+	if _, err := buffered.WriteString(response.String()); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if err := buffered.Flush(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	return newConn(netConn, buffered.Reader, false, config), nil
+}
+
+func Dial(rawURL string, header http.Header, config Config) (*Conn, *http.Response, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var netConn net.Conn
+	switch target.Scheme {
+	case "ws":
+		netConn, err = net.Dial("tcp", hostPort(target, "80"))
+	case "wss":
+		netConn, err = tls.Dial("tcp", hostPort(target, "443"), &tls.Config{ServerName: target.Hostname()})
+	default:
+		return nil, nil, HandshakeError{Status: http.StatusBadRequest, Message: "unsupported scheme " + target.Scheme}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	request := &http.Request{
+		Method:     http.MethodGet,
+		URL:        target,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       target.Host,
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := request.Write(netConn); err != nil {
+		netConn.Close()
+		return nil, nil, err
+	}
+	reader := bufio.NewReader(netConn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		netConn.Close()
+		return nil, nil, err
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		netConn.Close()
+		return nil, response, HandshakeError{Status: response.StatusCode, Message: "handshake failed with status " + strconv.Itoa(response.StatusCode)}
+	}
+	if response.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		netConn.Close()
+		return nil, response, HandshakeError{Status: response.StatusCode, Message: "handshake returned an invalid Sec-WebSocket-Accept"}
+	}
+	return newConn(netConn, reader, true, config), response, nil
+}
+
+func Subprotocols(request *http.Request) []string {
+	var protocols []string
+	for _, value := range request.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(value, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+func selectSubprotocol(requested []string, supported []string) string {
+	for _, protocol := range requested {
+		for _, candidate := range supported {
+			if protocol == candidate {
+				return protocol
+			}
+		}
+	}
+	return ""
+}
+
+func sameOrigin(origin string, host string) bool {
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, host)
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + acceptGuid))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func headerContainsToken(header http.Header, name string, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hostPort(target *url.URL, defaultPort string) string {
+	if target.Port() != "" {
+		return target.Host
+	}
+	return net.JoinHostPort(target.Hostname(), defaultPort)
+}
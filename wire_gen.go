@@ -8,12 +8,12 @@ package main
 
 import (
 	"github.com/go-playground/validator/v10"
-	"net/http"
 	"project-restful-api/app"
 	"project-restful-api/controller"
 	"project-restful-api/middleware"
 	"project-restful-api/repository"
 	"project-restful-api/service"
+	"project-restful-api/webhook"
 )
 
 import (
@@ -22,14 +22,60 @@ import (
 
 // Injectors from injector_api.go:
 
-func InitializeServer() *http.Server {
-	categoryRepository := repository.NewCategoryRepository()
+func InitializeApplication() *Application {
+	queryLogConfig := app.NewQueryLogConfig()
+	queryLog := repository.NewQueryLog(queryLogConfig)
+	categoryRepository := repository.NewCategoryRepository(queryLog)
+	todoRepository := repository.NewTodoRepository(queryLog)
 	db := app.NewDB()
 	validate := validator.New()
-	categoryService := service.NewCategoryService(categoryRepository, db, validate)
-	categoryController := controller.NewCategoryController(categoryService)
-	router := app.NewRouter(categoryController)
-	authMiddleware := middleware.NewAuthMiddleware(router)
-	server := NewServer(authMiddleware)
-	return server
+	broker := app.NewEventBroker()
+	webhookRepository := repository.NewWebhookRepository(queryLog)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(queryLog)
+	webhookConfig := app.NewWebhookConfig()
+	webhookService := service.NewWebhookService(webhookRepository, webhookDeliveryRepository, db, validate, webhookConfig)
+	categoryService := service.NewCategoryService(categoryRepository, todoRepository, db, validate, broker, webhookService)
+	categoryController := controller.NewCategoryController(categoryService, validate)
+	schema := controller.NewGraphqlSchema(categoryService)
+	limits := app.NewGraphqlLimits()
+	graphqlController := controller.NewGraphqlController(schema, limits)
+	rpcController := controller.NewRpcController(categoryService, validate)
+	serverConfig := app.NewServerConfig()
+	streamConfig := app.NewEventStreamConfig(serverConfig)
+	eventController := controller.NewEventController(broker, streamConfig)
+	corsConfig := app.NewCorsConfig()
+	config := app.NewWebSocketConfig(corsConfig)
+	webSocketController := controller.NewWebSocketController(categoryService, broker, config)
+	webhookController := controller.NewWebhookController(webhookService, validate)
+	authGuardConfig := app.NewAuthGuardConfig()
+	authAlertHook := app.NewAuthAlertHook()
+	authGuard := middleware.NewAuthGuard(authGuardConfig, authAlertHook)
+	userRepository := repository.NewUserRepository()
+	sessionRepository := repository.NewSessionRepository()
+	userService := service.NewUserService(userRepository, sessionRepository, db, validate, authGuard)
+	authController := controller.NewAuthController(userService)
+	twoFactorPolicy := app.NewTwoFactorPolicy()
+	adminController := controller.NewAdminController(authGuard, twoFactorPolicy, userService, queryLog, validate)
+	readiness := app.NewReadiness()
+	buildInfo := app.NewBuildInfo()
+	healthService := service.NewHealthService(db, readiness, buildInfo)
+	healthController := controller.NewHealthController(healthService)
+	v := app.NewApiKeys()
+	hmacConfig := app.NewHmacConfig()
+	hmacVerifier := middleware.NewHmacVerifier(hmacConfig)
+	trustedProxies := app.NewTrustedProxies()
+	authMiddleware := middleware.NewAuthMiddleware(v, sessionRepository, twoFactorPolicy, authGuard, hmacVerifier, trustedProxies)
+	rateLimitStore := middleware.NewMemoryRateLimitStore()
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(rateLimitStore, trustedProxies)
+	rateLimits := app.NewRateLimits()
+	apiVersions := app.NewApiVersions()
+	router := app.NewRouter(categoryController, graphqlController, rpcController, eventController, webSocketController, webhookController, authController, adminController, healthController, authMiddleware, rateLimitMiddleware, rateLimits, apiVersions)
+	tracingConfig := app.NewTracingConfig()
+	tracer := app.NewTracer(tracingConfig)
+	compressionConfig := app.NewCompressionConfig()
+	handler := app.NewHandler(router, trustedProxies, tracer, serverConfig, corsConfig, compressionConfig, apiVersions)
+	server := NewServer(handler, serverConfig)
+	dispatcher := webhook.NewDispatcher(webhookService, broker, webhookConfig)
+	mainApplication := NewApplication(server, db, readiness, tracer, broker, dispatcher, serverConfig)
+	return mainApplication
 }